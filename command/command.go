@@ -0,0 +1,169 @@
+// Package command builds a tree of commands and subcommands, like "git remote add", on top of the
+// options and params packages.
+package command
+
+import (
+    "fmt"
+    "io"
+    "strings"
+
+    "github.com/mauricelam/gocmdln/i18n"
+    "github.com/mauricelam/gocmdln/options"
+    "github.com/mauricelam/gocmdln/params"
+)
+
+// Command is a command or a subcommand, with its own options and positional parameters. A command
+// with subcommands should use the StrictOrder mode for its options, so that the options after the
+// subcommand name are parsed by the subcommand.
+type Command struct {
+    Name string
+    Aliases []string
+    // Short is a one line description of the command
+    Short string
+    // Long is a longer description of the command, which can span multiple lines
+    Long string
+    // Hidden commands can be used, but are not listed in the help or suggested
+    Hidden bool
+
+    Options *options.OptionSet
+    Params *params.ParamSet
+
+    // Run runs the command after its arguments are parsed by Execute
+    Run RunFunc
+    // PreRun and PostRun are run before and after Run
+    PreRun RunFunc
+    PostRun RunFunc
+    // PersistentPreRun and PersistentPostRun are run before and after Run of this command and all
+    // of its subcommands
+    PersistentPreRun RunFunc
+    PersistentPostRun RunFunc
+
+    // ExitCode maps the error of Execute to an exit code. If nil, the ExitCode of the parent
+    // command is used, or DefaultExitCode.
+    ExitCode func(err error) int
+    // Stdout and Stderr are the output streams of the command. If nil, the streams of the parent
+    // command are used, or os.Stdout and os.Stderr.
+    Stdout io.Writer
+    Stderr io.Writer
+
+    // Renderer renders the help and errors of the command. If nil, the Renderer of the parent
+    // command is used.
+    Renderer *Renderer
+    // HelpTemplate is the text/template of the help of the command, executed with a HelpContext.
+    // If empty, the HelpTemplate of the parent command is used, or DefaultHelpTemplate.
+    HelpTemplate string
+
+    parent *Command
+    commands []*Command
+}
+
+// AddCommand adds subcommands to the command. It panics if a subcommand already has a parent.
+func (c *Command) AddCommand(commands ...*Command) {
+    for _, cmd := range commands {
+        if cmd.parent != nil {
+            panic(fmt.Sprintf("Command %s already added to %s", cmd.Name, cmd.parent.Path()))
+        }
+        cmd.parent = c
+        c.commands = append(c.commands, cmd)
+    }
+}
+
+// Commands returns the subcommands of the command.
+func (c *Command) Commands() []*Command {
+    return c.commands
+}
+
+// Parent returns the command this command was added to, or nil for the root command.
+func (c *Command) Parent() *Command {
+    return c.parent
+}
+
+// Root returns the root of the command tree.
+func (c *Command) Root() *Command {
+    for c.parent != nil {
+        c = c.parent
+    }
+    return c
+}
+
+// Path returns the names of the commands from the root to this command, e.g. "git remote add".
+func (c *Command) Path() string {
+    if c.parent == nil {
+        return c.Name
+    }
+    return c.parent.Path() + " " + c.Name
+}
+
+// Lookup returns the subcommand with the name or alias, or nil if there is none.
+func (c *Command) Lookup(name string) *Command {
+    for _, cmd := range c.commands {
+        if cmd.Name == name {
+            return cmd
+        }
+        for _, alias := range cmd.Aliases {
+            if alias == name {
+                return cmd
+            }
+        }
+    }
+    return nil
+}
+
+// commandNames returns the names of the subcommands that are not hidden, for suggestions
+func (c *Command) commandNames() []string {
+    var names []string
+    for _, cmd := range c.commands {
+        if !cmd.Hidden {
+            names = append(names, cmd.Name)
+        }
+    }
+    return names
+}
+
+// Parse parses argv, which does not include the program name, by parsing the options of each
+// command and then descending into the subcommand named by the first positional argument, until
+// the positional arguments are parsed by the Params of the last command, which is returned. The
+// positional arguments are parsed with options.OptionSet.ParsePositionals, so they can be recorded
+// or replayed with the Record and Replay options.
+//
+// If the first positional argument is not the name of a subcommand and the command has no Params,
+// the returned *params.ArgumentError suggests the most similar subcommands.
+func (c *Command) Parse(argv []string) (*Command, error) {
+    cmd := c
+    for {
+        positionals := argv
+        if cmd.Options != nil {
+            var err error
+            positionals, err = cmd.Options.Parse(argv)
+            if err != nil { return cmd, err }
+        }
+        if len(cmd.commands) > 0 {
+            if len(positionals) > 0 {
+                if sub := cmd.Lookup(positionals[0]); sub != nil {
+                    cmd, argv = sub, positionals[1:]
+                    continue
+                }
+                if cmd.Params == nil {
+                    message := i18n.Sprintf(i18n.UnknownCommand, positionals[0], cmd.Path())
+                    err := params.NewArgumentError(nil, "", params.NewSuggestionError(message, positionals[0], cmd.commandNames()))
+                    err.Args = positionals[:1]
+                    return cmd, err
+                }
+            } else if cmd.Params == nil {
+                return cmd, params.NewArgumentError(params.ErrMissingArgument, "", i18n.Errorf(i18n.MissingCommand, cmd.Path(), strings.Join(cmd.commandNames(), ", ")))
+            }
+        }
+        if cmd.Params == nil {
+            if len(positionals) > 0 {
+                err := params.NewArgumentError(params.ErrTooManyArguments, "", i18n.Nerrorf(i18n.TooManyArguments, len(positionals), len(positionals)))
+                err.Args = positionals
+                return cmd, err
+            }
+            return cmd, nil
+        }
+        if cmd.Options != nil {
+            return cmd, cmd.Options.ParsePositionals(positionals, cmd.Params)
+        }
+        return cmd, cmd.Params.Parse(positionals)
+    }
+}
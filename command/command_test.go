@@ -0,0 +1,87 @@
+package command
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/mauricelam/gocmdln/i18n"
+    "github.com/mauricelam/gocmdln/options"
+    "github.com/mauricelam/gocmdln/params"
+)
+
+func init() {
+    // The tests compare the English messages, regardless of the locale of the environment
+    i18n.SetLocale("en")
+}
+
+func newGit() (*Command, *bool, *[]string) {
+    git := &Command{ Name: "git", Options: new(options.OptionSet) }
+    verbose := git.Options.Bool("verbose", 'v', "be verbose")
+    add := &Command{ Name: "add", Short: "Add file contents to the index", Params: new(params.ParamSet) }
+    paths := add.Params.StringList("pathspec", true, nil)
+    remote := &Command{ Name: "remote", Short: "Manage set of tracked repositories" }
+    remote.AddCommand(&Command{ Name: "add", Params: new(params.ParamSet) })
+    git.AddCommand(add, remote, &Command{ Name: "status", Aliases: []string { "st" } }, &Command{ Name: "stash" })
+    return git, verbose, paths
+}
+
+func TestParseSubcommand(t *testing.T) {
+    // Setup
+    git, verbose, paths := newGit()
+
+    // Execution
+    cmd, err := git.Parse([]string { "-v", "add", "a.go", "b.go" })
+
+    // Assertions
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    if cmd.Path() != "git add" { t.Errorf("Unexpected command: %s", cmd.Path()) }
+    if !*verbose { t.Errorf("verbose should be set") }
+    if !reflect.DeepEqual(*paths, []string { "a.go", "b.go" }) { t.Errorf("Unexpected paths: %v", *paths) }
+}
+
+func TestParseNestedAndAlias(t *testing.T) {
+    git, _, _ := newGit()
+
+    if cmd, err := git.Parse([]string { "st" }); err != nil || cmd.Name != "status" {
+        t.Errorf("Unexpected result: %v %v", cmd, err)
+    }
+    if cmd, err := git.Parse([]string { "remote", "add" }); err != nil || cmd.Path() != "git remote add" {
+        t.Errorf("Unexpected result: %v %v", cmd, err)
+    }
+}
+
+func TestUnknownCommandSuggestions(t *testing.T) {
+    // Setup
+    git, _, _ := newGit()
+
+    // Execution
+    cmd, err := git.Parse([]string { "stats" })
+
+    // Assertions
+    if cmd != git { t.Errorf("Unexpected command: %v", cmd) }
+    expected := `Unknown command "stats" for "git". Did you mean "status"?`
+    if err == nil || err.Error() != expected { t.Errorf("Unexpected error: %v", err) }
+    argErr, ok := err.(*params.ArgumentError)
+    if !ok || !reflect.DeepEqual(argErr.Suggestions, []string { "status" }) {
+        t.Errorf("Unexpected suggestions: %#v", err)
+    }
+}
+
+func TestMissingCommand(t *testing.T) {
+    git, _, _ := newGit()
+
+    _, err := git.Parse([]string {})
+
+    if err == nil || err.Error() != `Missing command for "git", expected one of add, remote, status, stash` {
+        t.Errorf("Unexpected error: %v", err)
+    }
+}
+
+func TestAddCommandTwice(t *testing.T) {
+    defer func() {
+        if recover() == nil { t.Errorf("AddCommand should panic") }
+    }()
+    sub := &Command{ Name: "sub" }
+    (&Command{ Name: "a" }).AddCommand(sub)
+    (&Command{ Name: "b" }).AddCommand(sub)
+}
@@ -0,0 +1,96 @@
+package command
+
+import (
+    "strings"
+
+    "github.com/mauricelam/gocmdln/options"
+    "github.com/mauricelam/gocmdln/params"
+)
+
+// optionArgument returns the option whose argument is the next argument after arg, e.g. the option
+// of "--file" or "-vf" if -f takes a required argument, or nil if arg includes its own argument.
+func optionArgument(set *options.OptionSet, arg string) *options.Option {
+    if set == nil {
+        return nil
+    }
+    if strings.HasPrefix(arg, "--") {
+        if strings.Contains(arg, "=") { return nil }
+        if opt := set.Lookup(arg[2:]); opt != nil && opt.Argument == options.RequiredArgument {
+            return opt
+        }
+        return nil
+    }
+    shorts := []rune(arg[1:])
+    for i, short := range shorts {
+        opt := set.Lookup(string(short))
+        if opt == nil || opt.Argument == options.OptionalArgument { return nil }
+        if opt.Argument == options.RequiredArgument {
+            // The rest of the cluster is the argument of the option
+            if i == len(shorts) - 1 { return opt }
+            return nil
+        }
+    }
+    return nil
+}
+
+// Complete returns the completions of the partial argument after args, which do not include the
+// program name. The arguments are parsed like Parse to find the subcommand, and the partial
+// argument is completed as the name of an option, the argument of an option whose Value is a
+// params.Completer, the name of a subcommand, or a positional parameter using
+// params.ParamSet.Complete.
+func (c *Command) Complete(args []string, partial string) []string {
+    cmd := c
+    var positionals []string
+    var pending *options.Option
+    for i := 0; i < len(args); i++ {
+        arg := args[i]
+        pending = nil
+        switch {
+        case arg == "--":
+            positionals = append(positionals, args[i + 1:]...)
+            i = len(args)
+        case options.IsOption(arg):
+            if pending = optionArgument(cmd.Options, arg); pending != nil && i + 1 < len(args) {
+                // Skip the argument of the option
+                i++
+                pending = nil
+            }
+        case len(positionals) == 0 && cmd.Lookup(arg) != nil:
+            cmd = cmd.Lookup(arg)
+        default:
+            positionals = append(positionals, arg)
+        }
+    }
+
+    if pending != nil {
+        if completer, ok := pending.Value.(params.Completer); ok {
+            return completer.Complete(partial)
+        }
+        return nil
+    }
+    var completions []string
+    if strings.HasPrefix(partial, "-") {
+        if cmd.Options != nil {
+            for _, opt := range cmd.Options.Options() {
+                longs := []string { opt.Long }
+                for _, alias := range opt.Aliases {
+                    if len([]rune(alias)) > 1 { longs = append(longs, alias) }
+                }
+                for _, long := range longs {
+                    if long != "" && strings.HasPrefix("--" + long, partial) {
+                        completions = append(completions, "--" + long)
+                    }
+                }
+            }
+        }
+        return completions
+    }
+    if len(positionals) == 0 {
+        for _, name := range cmd.commandNames() {
+            if strings.HasPrefix(name, partial) {
+                completions = append(completions, name)
+            }
+        }
+    }
+    return append(completions, cmd.Params.Complete(append(positionals, partial))...)
+}
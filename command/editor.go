@@ -0,0 +1,214 @@
+package command
+
+import (
+    "bufio"
+    "errors"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+    "unicode"
+    "unicode/utf8"
+)
+
+// errInterrupted is returned by ReadLine when the line is cancelled with Ctrl-C
+var errInterrupted = errors.New("Interrupted")
+
+// lineEditor reads lines from a terminal, with emacs style editing keys, history and completion.
+// The terminal is put into raw mode while a line is read if it is an *os.File, otherwise the input
+// is interpreted like the keys of a terminal, which allows testing with a fake terminal.
+type lineEditor struct {
+    in *bufio.Reader
+    out io.Writer
+    // terminal is the file to put into raw mode, if any
+    terminal *os.File
+    history *[]string
+    // complete returns the completions of the partial argument after the arguments
+    complete func(args []string, partial string) []string
+
+    prompt string
+    line []rune
+    cursor int
+    historyIndex int
+    // edited is the line being edited before browsing the history
+    edited []rune
+}
+
+// ReadLine reads a line after writing the prompt. It returns io.EOF if Ctrl-D is pressed on an
+// empty line, and errInterrupted if Ctrl-C is pressed.
+func (e *lineEditor) ReadLine(prompt string) (string, error) {
+    if e.terminal != nil {
+        if restore, err := makeRaw(e.terminal); err == nil {
+            defer restore()
+        }
+    }
+    e.prompt, e.line, e.cursor = prompt, nil, 0
+    e.historyIndex, e.edited = len(*e.history), nil
+    e.refresh()
+    for {
+        r, _, err := e.in.ReadRune()
+        if err != nil {
+            if err == io.EOF && len(e.line) > 0 {
+                break
+            }
+            return "", err
+        }
+        switch r {
+        case '\r', '\n':
+            fmt.Fprint(e.out, "\n")
+            return string(e.line), nil
+        case 1: // Ctrl-A
+            e.cursor = 0
+        case 2: // Ctrl-B
+            e.moveCursor(-1)
+        case 3: // Ctrl-C
+            fmt.Fprint(e.out, "^C\n")
+            return "", errInterrupted
+        case 4: // Ctrl-D
+            if len(e.line) == 0 {
+                fmt.Fprint(e.out, "\n")
+                return "", io.EOF
+            }
+            e.delete(e.cursor, e.cursor + 1)
+        case 5: // Ctrl-E
+            e.cursor = len(e.line)
+        case 6: // Ctrl-F
+            e.moveCursor(1)
+        case 8, 127: // Ctrl-H, Backspace
+            e.delete(e.cursor - 1, e.cursor)
+        case '\t':
+            e.completeLine()
+        case 11: // Ctrl-K
+            e.delete(e.cursor, len(e.line))
+        case 14: // Ctrl-N
+            e.browseHistory(1)
+        case 16: // Ctrl-P
+            e.browseHistory(-1)
+        case 21: // Ctrl-U
+            e.delete(0, e.cursor)
+        case 23: // Ctrl-W
+            start := e.cursor
+            for start > 0 && unicode.IsSpace(e.line[start - 1]) { start-- }
+            for start > 0 && !unicode.IsSpace(e.line[start - 1]) { start-- }
+            e.delete(start, e.cursor)
+        case 27: // Escape
+            e.escape()
+        default:
+            if unicode.IsPrint(r) {
+                e.insert([]rune { r })
+            }
+        }
+        e.refresh()
+    }
+    fmt.Fprint(e.out, "\n")
+    return string(e.line), nil
+}
+
+// escape handles the escape sequences of the arrow and editing keys
+func (e *lineEditor) escape() {
+    r, _, err := e.in.ReadRune()
+    if err != nil || (r != '[' && r != 'O') { return }
+    r, _, err = e.in.ReadRune()
+    if err != nil { return }
+    if r >= '0' && r <= '9' {
+        // A sequence like "ESC [ 3 ~"
+        code := r
+        for r != '~' {
+            if r, _, err = e.in.ReadRune(); err != nil { return }
+        }
+        r = code
+    }
+    switch r {
+    case 'A':
+        e.browseHistory(-1)
+    case 'B':
+        e.browseHistory(1)
+    case 'C':
+        e.moveCursor(1)
+    case 'D':
+        e.moveCursor(-1)
+    case 'H', '1', '7':
+        e.cursor = 0
+    case 'F', '4', '8':
+        e.cursor = len(e.line)
+    case '3':
+        e.delete(e.cursor, e.cursor + 1)
+    }
+}
+
+// refresh redraws the prompt and the line, and moves the cursor to its position
+func (e *lineEditor) refresh() {
+    fmt.Fprintf(e.out, "\r%s%s\x1b[K", e.prompt, string(e.line))
+    if n := len(e.line) - e.cursor; n > 0 {
+        fmt.Fprintf(e.out, "\x1b[%dD", n)
+    }
+}
+
+func (e *lineEditor) moveCursor(n int) {
+    if cursor := e.cursor + n; cursor >= 0 && cursor <= len(e.line) {
+        e.cursor = cursor
+    }
+}
+
+func (e *lineEditor) insert(text []rune) {
+    line := append([]rune {}, e.line[:e.cursor]...)
+    line = append(line, text...)
+    e.line = append(line, e.line[e.cursor:]...)
+    e.cursor += len(text)
+}
+
+// delete deletes the runes from start to end, which are clamped to the line
+func (e *lineEditor) delete(start, end int) {
+    if start < 0 { start = 0 }
+    if end > len(e.line) { end = len(e.line) }
+    if start >= end { return }
+    e.line = append(e.line[:start:start], e.line[end:]...)
+    e.cursor = start
+}
+
+// browseHistory replaces the line with the entry of the history that is n entries after the
+// current one, where the entry after the last one is the line that was being edited.
+func (e *lineEditor) browseHistory(n int) {
+    history := *e.history
+    index := e.historyIndex + n
+    if index < 0 || index > len(history) { return }
+    if e.historyIndex == len(history) {
+        e.edited = e.line
+    }
+    e.historyIndex = index
+    if index == len(history) {
+        e.line = e.edited
+    } else {
+        e.line = []rune(history[index])
+    }
+    e.cursor = len(e.line)
+}
+
+// completeLine completes the argument before the cursor. The argument is extended to the longest
+// common prefix of the completions, followed by a space if there is only one. If it cannot be
+// extended, the completions are listed below the line.
+func (e *lineEditor) completeLine() {
+    if e.complete == nil { return }
+    args, partial, start := splitCompletion(string(e.line[:e.cursor]))
+    completions := e.complete(args, partial)
+    if len(completions) == 0 { return }
+    prefix := completions[0]
+    for _, completion := range completions[1:] {
+        for !strings.HasPrefix(completion, prefix) {
+            _, size := utf8.DecodeLastRuneInString(prefix)
+            prefix = prefix[:len(prefix) - size]
+        }
+    }
+    if len(completions) == 1 || len(prefix) > len(partial) {
+        replacement := quoteArg(prefix)
+        if len(completions) == 1 && !strings.HasSuffix(prefix, "/") {
+            replacement += " "
+        }
+        // start is a byte index of the line, but the line is edited by runes
+        startRune := len([]rune(string(e.line[:e.cursor])[:start]))
+        e.delete(startRune, e.cursor)
+        e.insert([]rune(replacement))
+        return
+    }
+    fmt.Fprintf(e.out, "\n%s\n", strings.Join(completions, "  "))
+}
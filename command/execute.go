@@ -0,0 +1,229 @@
+package command
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "os"
+    "os/signal"
+    "sync"
+    "syscall"
+
+    "github.com/mauricelam/gocmdln/options"
+    "github.com/mauricelam/gocmdln/params"
+)
+
+// RunFunc is the signature of Run and the hooks of a command. cmd is the command being executed,
+// which for the persistent hooks may be a subcommand of the command that defines them.
+type RunFunc func(ctx context.Context, cmd *Command) error
+
+// UsageError is an error caused by an invalid invocation of the command, which is reported with the
+// usage synopsis and the exit code 2. Run can return a UsageError for usage errors that cannot be
+// detected by the options and params, like mutually exclusive options.
+type UsageError struct {
+    err error
+}
+
+// NewUsageError creates a UsageError caused by err.
+func NewUsageError(err error) *UsageError {
+    return &UsageError{ err }
+}
+
+// UsageErrorf creates a UsageError with the formatted message.
+func UsageErrorf(format string, a ...interface{}) *UsageError {
+    return &UsageError{ fmt.Errorf(format, a...) }
+}
+
+func (e *UsageError) Error() string {
+    return e.err.Error()
+}
+
+// Cause (inheritDoc from causer interface)
+func (e *UsageError) Cause() error {
+    return e.err
+}
+
+// Unwrap returns the cause of the error, for errors.Is and errors.As.
+func (e *UsageError) Unwrap() error {
+    return e.err
+}
+
+// ExitCoder can be implemented by errors that have their own exit code.
+type ExitCoder interface {
+    ExitCode() int
+}
+
+// isUsageError returns whether err is an error in the invocation of the command
+func isUsageError(err error) bool {
+    var usageErr *UsageError
+    var argErr *params.ArgumentError
+    var optErr *options.OptionError
+    return errors.As(err, &usageErr) || errors.As(err, &argErr) || errors.As(err, &optErr)
+}
+
+// DefaultExitCode maps an error returned by Execute to an exit code: 0 for nil, the code of an
+// ExitCoder, like the recommended params.ExitCodes of a params.ArgumentError, 2 for a UsageError
+// or options.OptionError, and 1 otherwise.
+func DefaultExitCode(err error) int {
+    var exitCoder ExitCoder
+    switch {
+    case err == nil:
+        return 0
+    case errors.As(err, &exitCoder):
+        return exitCoder.ExitCode()
+    case isUsageError(err):
+        return 2
+    }
+    return 1
+}
+
+// exit is os.Exit, replaced in tests
+var exit = os.Exit
+
+// interruptSignals cancel the context of Execute
+var interruptSignals = []os.Signal { os.Interrupt, syscall.SIGTERM }
+
+// signalContext returns a context that is cancelled on the first interrupt signal. A second signal
+// exits the program immediately with the exit code 128 + the signal number. The returned function
+// returns the signal that was received, if any, and stop stops listening to the signals.
+func signalContext(parent context.Context) (ctx context.Context, received func() os.Signal, stop func()) {
+    ctx, cancel := context.WithCancel(parent)
+    signals := make(chan os.Signal, 2)
+    signal.Notify(signals, interruptSignals...)
+    done := make(chan struct{})
+    var mutex sync.Mutex
+    var first os.Signal
+    go func() {
+        for {
+            select {
+            case sig := <-signals:
+                mutex.Lock()
+                force := first != nil
+                if !force { first = sig }
+                mutex.Unlock()
+                if force {
+                    exit(signalExitCode(sig))
+                    return
+                }
+                cancel()
+            case <-done:
+                return
+            }
+        }
+    }()
+    received = func() os.Signal {
+        mutex.Lock()
+        defer mutex.Unlock()
+        return first
+    }
+    stop = func() {
+        signal.Stop(signals)
+        close(done)
+        cancel()
+    }
+    return ctx, received, stop
+}
+
+// signalExitCode returns the exit code of a program terminated by the signal, as in shells
+func signalExitCode(sig os.Signal) int {
+    if s, ok := sig.(syscall.Signal); ok {
+        return 128 + int(s)
+    }
+    return 1
+}
+
+// OutOrStdout returns the Stdout of the command or of its closest ancestor that has one, or
+// os.Stdout.
+func (c *Command) OutOrStdout() io.Writer {
+    for cmd := c; cmd != nil; cmd = cmd.parent {
+        if cmd.Stdout != nil { return cmd.Stdout }
+    }
+    return os.Stdout
+}
+
+// ErrOrStderr returns the Stderr of the command or of its closest ancestor that has one, or
+// os.Stderr.
+func (c *Command) ErrOrStderr() io.Writer {
+    for cmd := c; cmd != nil; cmd = cmd.parent {
+        if cmd.Stderr != nil { return cmd.Stderr }
+    }
+    return os.Stderr
+}
+
+// exitCode maps the error using the ExitCode of the command or its closest ancestor that has one
+func (c *Command) exitCode(err error) int {
+    for cmd := c; cmd != nil; cmd = cmd.parent {
+        if cmd.ExitCode != nil { return cmd.ExitCode(err) }
+    }
+    return DefaultExitCode(err)
+}
+
+// Execute parses the arguments of the program in os.Args and runs the command, and returns the exit
+// code for os.Exit. See ExecuteArgs.
+func (c *Command) Execute(ctx context.Context) int {
+    return c.ExecuteArgs(ctx, os.Args[1:])
+}
+
+// ExecuteArgs parses argv, which does not include the program name, runs the selected command and
+// returns the exit code.
+//
+// The hooks are run in the order: the PersistentPreRun of the root command down to the selected
+// command, PreRun, Run, PostRun, and the PersistentPostRun of the selected command up to the
+// root. The post hooks are only run if Run succeeds. If any of them fail, the error is written to
+// Stderr, with the usage synopsis for usage errors, and mapped to an exit code by ExitCode.
+//
+// The context passed to the hooks is cancelled when the program receives SIGINT or SIGTERM. A
+// second signal exits the program immediately. If the command fails after a signal, the exit code
+// is 128 + the signal number, as in shells.
+func (c *Command) ExecuteArgs(ctx context.Context, argv []string) int {
+    ctx, received, stop := signalContext(ctx)
+    defer stop()
+
+    cmd, err := c.Parse(argv)
+    if err == nil {
+        err = cmd.run(ctx)
+    }
+    if err == nil {
+        return 0
+    }
+    if isUsageError(err) {
+        cmd.PrintError(cmd.ErrOrStderr(), err)
+    } else {
+        cmd.renderer().RenderError(cmd.ErrOrStderr(), err, "")
+    }
+    if sig := received(); sig != nil {
+        return signalExitCode(sig)
+    }
+    return cmd.exitCode(err)
+}
+
+// ancestors returns the commands from the root to c
+func (c *Command) ancestors() []*Command {
+    var commands []*Command
+    for cmd := c; cmd != nil; cmd = cmd.parent {
+        commands = append([]*Command { cmd }, commands...)
+    }
+    return commands
+}
+
+// run runs the hooks and Run of the command
+func (c *Command) run(ctx context.Context) error {
+    commands := c.ancestors()
+    for _, cmd := range commands {
+        if cmd.PersistentPreRun != nil {
+            if err := cmd.PersistentPreRun(ctx, c); err != nil { return err }
+        }
+    }
+    for _, hook := range []RunFunc { c.PreRun, c.Run, c.PostRun } {
+        if hook != nil {
+            if err := hook(ctx, c); err != nil { return err }
+        }
+    }
+    for i := len(commands) - 1; i >= 0; i-- {
+        if commands[i].PersistentPostRun != nil {
+            if err := commands[i].PersistentPostRun(ctx, c); err != nil { return err }
+        }
+    }
+    return nil
+}
@@ -0,0 +1,107 @@
+package command
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "fmt"
+    "reflect"
+    "testing"
+
+    "github.com/mauricelam/gocmdln/params"
+)
+
+type exitCodeError int
+
+func (e exitCodeError) Error() string { return fmt.Sprintf("exit %d", int(e)) }
+
+func (e exitCodeError) ExitCode() int { return int(e) }
+
+func newHookedCommand(calls *[]string, runErr error) (*Command, *bytes.Buffer) {
+    hook := func(name string) RunFunc {
+        return func(ctx context.Context, cmd *Command) error {
+            *calls = append(*calls, name + ":" + cmd.Name)
+            return nil
+        }
+    }
+    var stderr bytes.Buffer
+    root := &Command{
+        Name: "tool",
+        PersistentPreRun: hook("root-pre"),
+        PersistentPostRun: hook("root-post"),
+        Stderr: &stderr,
+        Renderer: &Renderer{ Color: ColorNever },
+    }
+    group := &Command{ Name: "db", PersistentPreRun: hook("db-pre"), PersistentPostRun: hook("db-post") }
+    migrate := &Command{
+        Name: "migrate",
+        Params: new(params.ParamSet),
+        PreRun: hook("pre"),
+        PostRun: hook("post"),
+        Run: func(ctx context.Context, cmd *Command) error {
+            *calls = append(*calls, "run:" + cmd.Name)
+            return runErr
+        },
+    }
+    migrate.Params.Int("version", true, nil)
+    group.AddCommand(migrate)
+    root.AddCommand(group)
+    return root, &stderr
+}
+
+func TestExecuteHooks(t *testing.T) {
+    // Setup
+    var calls []string
+    root, stderr := newHookedCommand(&calls, nil)
+
+    // Execution
+    code := root.ExecuteArgs(context.Background(), []string { "db", "migrate", "3" })
+
+    // Assertions
+    if code != 0 { t.Errorf("Exit code should be 0, but was %d: %s", code, stderr) }
+    expected := []string { "root-pre:migrate", "db-pre:migrate", "pre:migrate", "run:migrate", "post:migrate", "db-post:migrate", "root-post:migrate" }
+    if !reflect.DeepEqual(calls, expected) { t.Errorf("Unexpected calls: %v", calls) }
+}
+
+func TestExecuteExitCodes(t *testing.T) {
+    cases := []struct {
+        name string
+        argv []string
+        runErr error
+        code int
+        stderr string
+    }{
+        { "argument error", []string { "db", "migrate", "x" }, nil, 2, "Error: strconv.ParseInt: parsing \"x\": invalid syntax\nUsage: tool db migrate [<version>]\n" },
+        { "unknown command", []string { "db", "migrat" }, nil, 2, "Error: Unknown command \"migrat\" for \"tool db\". Did you mean \"migrate\"?\nUsage: tool db <command>\n" },
+        { "usage error", []string { "db", "migrate" }, UsageErrorf("Missing version"), 2, "Error: Missing version\nUsage: tool db migrate [<version>]\n" },
+        { "run error", []string { "db", "migrate" }, errors.New("Connection refused"), 1, "Error: Connection refused\n" },
+        { "exit coder", []string { "db", "migrate" }, fmt.Errorf("Wrapped: %w", exitCodeError(5)), 5, "Error: Wrapped: exit 5\n" },
+    }
+    for _, c := range cases {
+        t.Run(c.name, func(t *testing.T) {
+            var calls []string
+            root, stderr := newHookedCommand(&calls, c.runErr)
+            code := root.ExecuteArgs(context.Background(), c.argv)
+            if code != c.code { t.Errorf("Exit code should be %d, but was %d", c.code, code) }
+            if stderr.String() != c.stderr { t.Errorf("Unexpected stderr: %q", stderr.String()) }
+        })
+    }
+}
+
+func TestCustomExitCode(t *testing.T) {
+    // Setup
+    var calls []string
+    root, _ := newHookedCommand(&calls, errors.New("failed"))
+    root.ExitCode = func(err error) int {
+        if DefaultExitCode(err) == 1 { return 70 }
+        return DefaultExitCode(err)
+    }
+
+    // Assertions
+    if code := root.ExecuteArgs(context.Background(), []string { "db", "migrate" }); code != 70 {
+        t.Errorf("Exit code should be 70, but was %d", code)
+    }
+    if code := root.ExecuteArgs(context.Background(), []string { "db" }); code != 2 {
+        t.Errorf("Exit code should be 2, but was %d", code)
+    }
+}
@@ -0,0 +1,46 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+// +build linux darwin dragonfly freebsd netbsd openbsd
+
+package command
+
+import (
+    "bytes"
+    "context"
+    "os"
+    "syscall"
+    "testing"
+    "time"
+)
+
+func TestExecuteSignals(t *testing.T) {
+    // Setup
+    exitCodes := make(chan int, 1)
+    exit = func(code int) { exitCodes <- code }
+    defer func() { exit = os.Exit }()
+    var stderr bytes.Buffer
+    cmd := &Command{
+        Name: "sleep",
+        Stderr: &stderr,
+        Renderer: &Renderer{ Color: ColorNever },
+        Run: func(ctx context.Context, cmd *Command) error {
+            syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+            <-ctx.Done()
+            // A second signal forces the exit
+            syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+            select {
+            case code := <-exitCodes:
+                exitCodes <- code
+            case <-time.After(5 * time.Second):
+            }
+            return ctx.Err()
+        },
+    }
+
+    // Execution
+    code := cmd.ExecuteArgs(context.Background(), []string {})
+
+    // Assertions
+    if code != 130 { t.Errorf("Exit code should be 130, but was %d", code) }
+    if forced := <-exitCodes; forced != 130 { t.Errorf("Forced exit code should be 130, but was %d", forced) }
+    if stderr.String() != "Error: context canceled\n" { t.Errorf("Unexpected stderr: %q", stderr.String()) }
+}
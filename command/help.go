@@ -0,0 +1,130 @@
+package command
+
+import (
+    "fmt"
+    "io"
+    "strings"
+
+    "github.com/mauricelam/gocmdln/i18n"
+    "github.com/mauricelam/gocmdln/params"
+)
+
+// HelpEntry is a row of a section of the help, e.g. an option and its description.
+type HelpEntry struct {
+    Name string
+    Description string
+}
+
+// HelpSection is a section of the help, e.g. the options.
+type HelpSection struct {
+    Heading string
+    Entries []HelpEntry
+}
+
+// HelpData is the content of the help of a command, independently of how it is rendered.
+type HelpData struct {
+    // Usage is the synopsis of the command, e.g. "git remote add [OPTION]... <name> <url>"
+    Usage string
+    Description string
+    Sections []HelpSection
+}
+
+// renderer returns the Renderer of the command, or of its closest ancestor that has one
+func (c *Command) renderer() *Renderer {
+    for cmd := c; cmd != nil; cmd = cmd.parent {
+        if cmd.Renderer != nil {
+            return cmd.Renderer
+        }
+    }
+    return defaultRenderer
+}
+
+// Synopsis returns the usage synopsis of the command, e.g. "git [OPTION]... <command>".
+func (c *Command) Synopsis() string {
+    synopsis := []string { c.Path() }
+    if c.Options != nil && len(c.Options.Options()) > 0 {
+        synopsis = append(synopsis, "[OPTION]...")
+    }
+    if len(c.commandNames()) > 0 {
+        if c.Params == nil {
+            synopsis = append(synopsis, "<command>")
+        } else {
+            synopsis = append(synopsis, "[<command>]")
+        }
+    }
+    if positional := c.Params.Synopsis(); positional != "" {
+        synopsis = append(synopsis, positional)
+    }
+    return strings.Join(synopsis, " ")
+}
+
+// Help returns the content of the help of the command: its subcommands, its positional arguments
+// grouped by params.Help.Group, and its options.
+func (c *Command) Help() HelpData {
+    data := HelpData{ Usage: c.Synopsis(), Description: c.Long }
+    if data.Description == "" { data.Description = c.Short }
+
+    var commands []HelpEntry
+    for _, cmd := range c.commands {
+        if !cmd.Hidden {
+            name := strings.Join(append([]string { cmd.Name }, cmd.Aliases...), ", ")
+            commands = append(commands, HelpEntry{ name, cmd.Short })
+        }
+    }
+    if len(commands) > 0 {
+        data.Sections = append(data.Sections, HelpSection{ i18n.Sprintf(i18n.CommandsHeading), commands })
+    }
+
+    if c.Params != nil {
+        var groups []string
+        entriesByGroup := make(map[string][]HelpEntry)
+        for _, paramSpec := range *c.Params {
+            if params.IsHidden(paramSpec) {
+                continue
+            }
+            group := params.HelpOf(paramSpec).Group
+            if _, ok := entriesByGroup[group]; !ok {
+                groups = append(groups, group)
+            }
+            name := strings.Trim(params.SpecSynopsis(paramSpec), "[]")
+            entriesByGroup[group] = append(entriesByGroup[group], HelpEntry{ name, params.Description(paramSpec) })
+        }
+        for _, group := range groups {
+            heading := group + ":"
+            if group == "" { heading = i18n.Sprintf(i18n.ArgumentsHeading) }
+            data.Sections = append(data.Sections, HelpSection{ heading, entriesByGroup[group] })
+        }
+    }
+
+    if c.Options != nil && len(c.Options.Options()) > 0 {
+        var options []HelpEntry
+        for _, opt := range c.Options.Options() {
+            options = append(options, HelpEntry{ opt.Synopsis(), opt.Usage })
+        }
+        data.Sections = append(data.Sections, HelpSection{ i18n.Sprintf(i18n.OptionsHeading), options })
+    }
+    return data
+}
+
+// RenderError writes the error to w, followed by the usage synopsis if it is not empty.
+func (r *Renderer) RenderError(w io.Writer, err error, usage string) {
+    width, color, theme := r.width(w), r.colored(w), r.theme()
+    label := i18n.Sprintf(i18n.ErrorLabel)
+    message := Wrap(label + " " + err.Error(), width)
+    fmt.Fprintf(w, "%s%s\n", theme.Error.Render(label, color), message[len(label):])
+    if usage != "" {
+        fmt.Fprintf(w, "%s %s\n", theme.Heading.Render(i18n.Sprintf(i18n.UsageHeading), color), usage)
+    }
+}
+
+// PrintHelp writes the help of the command to w, using the Renderer and the HelpTemplate of the
+// command. It returns an error if the template fails.
+func (c *Command) PrintHelp(w io.Writer) error {
+    return c.renderer().RenderHelpTemplate(w, c.helpTemplate(), HelpContext{ c.Help(), c, 0 })
+}
+
+// PrintError writes the error and the usage synopsis of the command to w, using the Renderer of
+// the command.
+func (c *Command) PrintError(w io.Writer, err error) {
+    c.renderer().RenderError(w, err, c.Synopsis())
+}
@@ -0,0 +1,121 @@
+package command
+
+import (
+    "bytes"
+    "errors"
+    "os"
+    "testing"
+
+    "github.com/mauricelam/gocmdln/options"
+    "github.com/mauricelam/gocmdln/params"
+)
+
+func newRemoteAdd() *Command {
+    remote := &Command{ Name: "remote", Renderer: &Renderer{ Width: 60, Color: ColorNever } }
+    add := &Command{
+        Name: "add",
+        Short: "Add a remote named <name> for the repository at <url>.",
+        Options: new(options.OptionSet),
+        Params: new(params.ParamSet),
+    }
+    add.Options.Bool("fetch", 'f', "Fetch the remote branches right after the remote information is set up.")
+    add.Options.String("track", 't', "", "Track only the branch")
+    add.Params.String("name", false, params.Help{ Short: "the name of the remote" })
+    add.Params.String("url", false, params.Help{ Short: "the URL to fetch from, which can be any URL supported by git", Group: "Location" })
+    remote.AddCommand(add, &Command{ Name: "remove", Aliases: []string { "rm" }, Short: "Remove the remote" }, &Command{ Name: "secret", Hidden: true })
+    return remote
+}
+
+func TestPrintHelp(t *testing.T) {
+    // Setup
+    remote := newRemoteAdd()
+
+    // Execution
+    var buf bytes.Buffer
+    remote.Lookup("add").PrintHelp(&buf)
+
+    // Assertions
+    expected := `Usage: remote add [OPTION]... <name> <url>
+
+Add a remote named <name> for the repository at <url>.
+
+Arguments:
+  <name>  the name of the remote
+
+Location:
+  <url>  the URL to fetch from, which can be any URL
+         supported by git
+
+Options:
+  -f, --fetch        Fetch the remote branches right after
+                     the remote information is set up.
+  -t, --track=TRACK  Track only the branch
+`
+    if buf.String() != expected {
+        t.Errorf("Unexpected help:\n%s", buf.String())
+    }
+}
+
+func TestPrintCommandsHelp(t *testing.T) {
+    // Setup
+    remote := newRemoteAdd()
+
+    // Execution
+    var buf bytes.Buffer
+    remote.PrintHelp(&buf)
+
+    // Assertions
+    expected := `Usage: remote <command>
+
+Commands:
+  add         Add a remote named <name> for the repository
+              at <url>.
+  remove, rm  Remove the remote
+`
+    if buf.String() != expected {
+        t.Errorf("Unexpected help:\n%s", buf.String())
+    }
+}
+
+func TestPrintErrorColor(t *testing.T) {
+    // Setup
+    remote := newRemoteAdd()
+    remote.Renderer.Color = ColorAlways
+
+    // Execution
+    var buf bytes.Buffer
+    remote.PrintError(&buf, errors.New("Something failed"))
+
+    // Assertions
+    expected := "\x1b[1;31mError:\x1b[0m Something failed\n\x1b[1mUsage:\x1b[0m remote <command>\n"
+    if buf.String() != expected {
+        t.Errorf("Unexpected error: %q", buf.String())
+    }
+}
+
+func TestColorDetection(t *testing.T) {
+    r := &Renderer{}
+    var buf bytes.Buffer
+    if r.colored(&buf) { t.Errorf("A buffer should not be colored") }
+
+    os.Setenv("NO_COLOR", "")
+    defer os.Unsetenv("NO_COLOR")
+    if r.colored(os.Stdout) { t.Errorf("NO_COLOR should disable colors") }
+    r.Color = ColorAlways
+    if !r.colored(&buf) { t.Errorf("ColorAlways should enable colors") }
+}
+
+func TestWidth(t *testing.T) {
+    var buf bytes.Buffer
+    os.Setenv("COLUMNS", "100")
+    defer os.Unsetenv("COLUMNS")
+    if width := (&Renderer{}).width(&buf); width != 100 { t.Errorf("Width should be 100, but was %d", width) }
+    if width := (&Renderer{ Width: 40 }).width(&buf); width != 40 { t.Errorf("Width should be 40, but was %d", width) }
+}
+
+func TestWrap(t *testing.T) {
+    wrapped := Wrap("the quick brown fox\njumps over the lazy dog", 10)
+    if expected := "the quick\nbrown fox\njumps over\nthe lazy\ndog"; wrapped != expected {
+        t.Errorf("Unexpected wrapped text:\n%s", wrapped)
+    }
+}
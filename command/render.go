@@ -0,0 +1,149 @@
+package command
+
+import (
+    "io"
+    "os"
+    "strconv"
+    "strings"
+    "unicode/utf8"
+)
+
+// DefaultWidth is the width that the help is wrapped to if the width of the terminal is unknown.
+const DefaultWidth = 80
+
+// ColorMode specifies when the help and errors are colored.
+type ColorMode int
+
+const (
+    // ColorAuto colors the output if it is a terminal, unless the NO_COLOR environment variable is
+    // set or TERM is "dumb"
+    ColorAuto ColorMode = iota
+    ColorAlways
+    ColorNever
+)
+
+// Style is an SGR escape sequence parameter, e.g. "1" for bold or "1;31" for bold red. An empty
+// Style leaves the text unstyled.
+type Style string
+
+// Render wraps the text in the escape sequences of the style, if color is true.
+func (s Style) Render(text string, color bool) string {
+    if !color || s == "" || text == "" {
+        return text
+    }
+    return "\x1b[" + string(s) + "m" + text + "\x1b[0m"
+}
+
+// Theme is the styles of the parts of the help and errors.
+type Theme struct {
+    Heading Style
+    // Name is the style of the names of commands, options and arguments
+    Name Style
+    Error Style
+}
+
+// DefaultTheme is the Theme used by a Renderer without a theme.
+var DefaultTheme = Theme{
+    Heading: "1",
+    Name: "36",
+    Error: "1;31",
+}
+
+// Renderer controls how the help and errors of a command are rendered. A Renderer set on a command
+// is also used by its subcommands, unless they set their own.
+type Renderer struct {
+    // Width is the number of columns the output is wrapped to. If 0, the width of the terminal is
+    // used, or the COLUMNS environment variable, or DefaultWidth.
+    Width int
+    Color ColorMode
+    // Theme is the styles of the output. If nil, DefaultTheme is used.
+    Theme *Theme
+}
+
+var defaultRenderer = &Renderer{}
+
+// width returns the number of columns to wrap the output to w to
+func (r *Renderer) width(w io.Writer) int {
+    if r.Width > 0 {
+        return r.Width
+    }
+    if f, ok := w.(*os.File); ok {
+        if cols, isTerminal := terminalSize(f); isTerminal && cols > 0 {
+            return cols
+        }
+    }
+    if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+        return cols
+    }
+    return DefaultWidth
+}
+
+// colored returns whether the output to w is colored
+func (r *Renderer) colored(w io.Writer) bool {
+    switch r.Color {
+    case ColorAlways:
+        return true
+    case ColorNever:
+        return false
+    }
+    if _, noColor := os.LookupEnv("NO_COLOR"); noColor || os.Getenv("TERM") == "dumb" {
+        return false
+    }
+    f, ok := w.(*os.File)
+    if !ok {
+        return false
+    }
+    _, isTerminal := terminalSize(f)
+    return isTerminal
+}
+
+func (r *Renderer) theme() Theme {
+    if r.Theme == nil {
+        return DefaultTheme
+    }
+    return *r.Theme
+}
+
+// Wrap wraps the text into lines of at most width columns, breaking at spaces. Existing line
+// breaks are kept, and words longer than the width are not broken.
+func Wrap(text string, width int) string {
+    var lines []string
+    for _, paragraph := range strings.Split(text, "\n") {
+        line, lineWidth := "", 0
+        for _, word := range strings.Fields(paragraph) {
+            wordWidth := utf8.RuneCountInString(word)
+            if lineWidth > 0 && lineWidth + 1 + wordWidth > width {
+                lines = append(lines, line)
+                line, lineWidth = "", 0
+            }
+            if lineWidth > 0 {
+                line += " "
+                lineWidth++
+            }
+            line += word
+            lineWidth += wordWidth
+        }
+        lines = append(lines, line)
+    }
+    return strings.Join(lines, "\n")
+}
+
+// Indent prefixes every non-empty line of the text with the number of spaces.
+func Indent(text string, spaces int) string {
+    prefix := strings.Repeat(" ", spaces)
+    lines := strings.Split(text, "\n")
+    for i, line := range lines {
+        if line != "" {
+            lines[i] = prefix + line
+        }
+    }
+    return strings.Join(lines, "\n")
+}
+
+// Pad pads the text with spaces to the width in columns.
+func Pad(text string, width int) string {
+    if n := width - utf8.RuneCountInString(text); n > 0 {
+        return text + strings.Repeat(" ", n)
+    }
+    return text
+}
@@ -0,0 +1,227 @@
+package command
+
+import (
+    "bufio"
+    "context"
+    "fmt"
+    "io"
+    "io/ioutil"
+    "os"
+    "strings"
+
+    "github.com/mauricelam/gocmdln/i18n"
+    "github.com/mauricelam/gocmdln/params"
+)
+
+// EditMode specifies when the Shell edits the input lines.
+type EditMode int
+
+const (
+    // EditAuto edits the lines if In is a terminal
+    EditAuto EditMode = iota
+    // EditAlways interprets the input as the keys of a terminal, even if it is not one, e.g. to
+    // test the shell with a fake terminal over a pipe
+    EditAlways
+    // EditNever reads plain lines without prompts, e.g. for scripts
+    EditNever
+)
+
+// DefaultMaxHistory is the number of lines kept in the history if MaxHistory is 0.
+const DefaultMaxHistory = 500
+
+// Shell runs the subcommands of a command interactively, reading one command line at a time and
+// executing it with ExecuteArgs. Lines are split into arguments like a POSIX shell does, see
+// SplitLine, and a line that ends inside quotes or with a backslash continues on the next line.
+//
+// When editing, the line editor supports the usual emacs style keys, the history with the up and
+// down arrows, and completion with Tab, using Command.Complete.
+//
+// The shell has two built-in commands, unless the command has subcommands of the same names:
+// "help [<command>...]" prints the help of the command or of a subcommand, and "exit" ends the
+// shell, as does Ctrl-D on an empty line.
+type Shell struct {
+    // Command is the command whose subcommands are run by the shell
+    Command *Command
+    // Prompt is written before each line. If empty, the name of the command followed by "> " is
+    // used.
+    Prompt string
+    // ContinuationPrompt is written before the continuation lines. If empty, "> " is used.
+    ContinuationPrompt string
+
+    // In is the input of the shell. If nil, os.Stdin is used.
+    In io.Reader
+    // Out is where the prompts and the edited lines are written. If nil, the Stdout of the command
+    // is used. The output of the commands is written to their own Stdout and Stderr.
+    Out io.Writer
+    Edit EditMode
+
+    // History is the lines previously entered, oldest first. Entered lines are appended to it.
+    History []string
+    // MaxHistory is the number of lines kept in the history. If 0, DefaultMaxHistory is used.
+    MaxHistory int
+    // HistoryFile, if not empty, is the file that the history is loaded from when the shell starts,
+    // and that the entered lines are appended to.
+    HistoryFile string
+}
+
+// readFunc reads a line after writing the prompt
+type readFunc func(prompt string) (string, error)
+
+// Run runs the shell until the input ends, "exit" is entered, or ctx is done. The error of a
+// command is written to its Stderr and does not end the shell.
+func (s *Shell) Run(ctx context.Context) error {
+    var in io.Reader = os.Stdin
+    if s.In != nil { in = s.In }
+    out := s.Out
+    if out == nil { out = s.Command.OutOrStdout() }
+    if err := s.loadHistory(); err != nil { return err }
+
+    reader := bufio.NewReader(in)
+    read := func(prompt string) (string, error) {
+        line, err := reader.ReadString('\n')
+        if err == io.EOF && line != "" { err = nil }
+        return strings.TrimRight(line, "\r\n"), err
+    }
+    if s.editing(in) {
+        editor := &lineEditor{ in: reader, out: out, history: &s.History, complete: s.complete }
+        editor.terminal, _ = in.(*os.File)
+        read = editor.ReadLine
+    }
+
+    for ctx.Err() == nil {
+        argv, err := s.readArgs(read)
+        switch {
+        case err == errInterrupted:
+            continue
+        case err == io.EOF:
+            return nil
+        case err != nil:
+            return err
+        }
+        if len(argv) == 0 {
+            continue
+        }
+        if s.Command.Lookup(argv[0]) == nil {
+            switch argv[0] {
+            case "exit":
+                return nil
+            case "help":
+                s.help(argv[1:])
+                continue
+            }
+        }
+        s.Command.ExecuteArgs(ctx, argv)
+    }
+    return ctx.Err()
+}
+
+// editing returns whether the lines read from in are edited
+func (s *Shell) editing(in io.Reader) bool {
+    switch s.Edit {
+    case EditAlways:
+        return true
+    case EditNever:
+        return false
+    }
+    f, ok := in.(*os.File)
+    if !ok {
+        return false
+    }
+    _, isTerminal := terminalSize(f)
+    return isTerminal
+}
+
+// readArgs reads a command line, including its continuation lines, and splits it into arguments.
+// The lines are added to the history.
+func (s *Shell) readArgs(read readFunc) ([]string, error) {
+    prompt := s.Prompt
+    if prompt == "" { prompt = s.Command.Name + "> " }
+    line, err := read(prompt)
+    if err != nil { return nil, err }
+    s.addHistory(line)
+    for {
+        argv, err := SplitLine(line)
+        if err != ErrIncomplete {
+            return argv, err
+        }
+        prompt := s.ContinuationPrompt
+        if prompt == "" { prompt = "> " }
+        next, err := read(prompt)
+        if err != nil { return nil, err }
+        s.addHistory(next)
+        line += "\n" + next
+    }
+}
+
+// help prints the help of the subcommand named by names
+func (s *Shell) help(names []string) {
+    cmd := s.Command
+    for _, name := range names {
+        sub := cmd.Lookup(name)
+        if sub == nil {
+            message := i18n.Sprintf(i18n.UnknownCommand, name, cmd.Path())
+            cmd.renderer().RenderError(cmd.ErrOrStderr(), params.NewSuggestionError(message, name, cmd.commandNames()), "")
+            return
+        }
+        cmd = sub
+    }
+    if err := cmd.PrintHelp(cmd.OutOrStdout()); err != nil {
+        cmd.renderer().RenderError(cmd.ErrOrStderr(), err, "")
+    }
+}
+
+// complete completes the partial argument with Command.Complete, and the built-in commands
+func (s *Shell) complete(args []string, partial string) []string {
+    completions := s.Command.Complete(args, partial)
+    if len(args) == 0 {
+        for _, builtin := range []string { "help", "exit" } {
+            if strings.HasPrefix(builtin, partial) && s.Command.Lookup(builtin) == nil {
+                completions = append(completions, builtin)
+            }
+        }
+    }
+    return completions
+}
+
+func (s *Shell) maxHistory() int {
+    if s.MaxHistory > 0 {
+        return s.MaxHistory
+    }
+    return DefaultMaxHistory
+}
+
+// loadHistory prepends the lines of the HistoryFile to the History
+func (s *Shell) loadHistory() error {
+    if s.HistoryFile == "" {
+        return nil
+    }
+    content, err := ioutil.ReadFile(s.HistoryFile)
+    if os.IsNotExist(err) { return nil }
+    if err != nil { return err }
+    lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+    if len(content) == 0 { lines = nil }
+    s.History = append(lines, s.History...)
+    if n := len(s.History) - s.maxHistory(); n > 0 {
+        s.History = s.History[n:]
+    }
+    return nil
+}
+
+// addHistory appends the line to the History and the HistoryFile, unless it is empty or the same
+// as the last line
+func (s *Shell) addHistory(line string) {
+    if strings.TrimSpace(line) == "" || (len(s.History) > 0 && s.History[len(s.History) - 1] == line) {
+        return
+    }
+    s.History = append(s.History, line)
+    if n := len(s.History) - s.maxHistory(); n > 0 {
+        s.History = s.History[n:]
+    }
+    if s.HistoryFile != "" {
+        // The history is best effort, so errors are ignored
+        if f, err := os.OpenFile(s.HistoryFile, os.O_APPEND | os.O_CREATE | os.O_WRONLY, 0600); err == nil {
+            fmt.Fprintln(f, line)
+            f.Close()
+        }
+    }
+}
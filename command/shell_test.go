@@ -0,0 +1,218 @@
+package command
+
+import (
+    "bytes"
+    "context"
+    "fmt"
+    "io"
+    "io/ioutil"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+
+    "github.com/mauricelam/gocmdln/options"
+    "github.com/mauricelam/gocmdln/params"
+)
+
+// newShellCommand creates a command tree that records the commands it runs
+func newShellCommand(runs *[]string) (*Command, *bytes.Buffer) {
+    var output bytes.Buffer
+    root := &Command{ Name: "git", Stdout: &output, Stderr: &output, Renderer: &Renderer{ Width: 60, Color: ColorNever } }
+    remote := &Command{ Name: "remote", Short: "Manage remotes" }
+    add := &Command{ Name: "add", Short: "Add a remote", Options: new(options.OptionSet), Params: new(params.ParamSet) }
+    fetch := add.Options.Bool("fetch", 'f', "Fetch the remote")
+    track := add.Options.String("track", 't', "", "Track only the branch")
+    name := add.Params.String("name", false, nil)
+    url := add.Params.String("url", false, nil)
+    add.Run = func(ctx context.Context, cmd *Command) error {
+        *runs = append(*runs, fmt.Sprintf("add %q %q fetch=%v track=%q", *name, *url, *fetch, *track))
+        return nil
+    }
+    checkout := &Command{ Name: "checkout", Params: new(params.ParamSet) }
+    branch := checkout.Params.Choice("branch", []string { "main", "master", "dev" }, false, nil)
+    checkout.Run = func(ctx context.Context, cmd *Command) error {
+        *runs = append(*runs, "checkout " + *branch)
+        return nil
+    }
+    remote.AddCommand(add)
+    root.AddCommand(remote, checkout)
+    return root, &output
+}
+
+func TestShellFakeTerminal(t *testing.T) {
+
+    // Test setup
+
+    var runs []string
+    root, output := newShellCommand(&runs)
+    in, keys := io.Pipe()
+    var screen bytes.Buffer
+    shell := &Shell{ Command: root, In: in, Out: &screen, Edit: EditAlways }
+    go func() {
+        io.WriteString(keys, "remote add -f origin https://a\r")
+        // Up arrow recalls the line, Ctrl-W deletes the URL, then "-f " is deleted
+        io.WriteString(keys, "\x1b[A\x17https://b\x01" + strings.Repeat("\x1b[C", 11) + "\x1b[3~\x1b[3~\x04\r")
+        io.WriteString(keys, "help remote\r")
+        io.WriteString(keys, "remote add unfinished\x03")
+        io.WriteString(keys, "\x04")
+        keys.Close()
+    }()
+
+    // Test execution
+
+    err := shell.Run(context.Background())
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    expected := []string {
+        `add "origin" "https://a" fetch=true track=""`,
+        `add "origin" "https://b" fetch=false track=""`,
+    }
+    if !reflect.DeepEqual(runs, expected) { t.Errorf("Unexpected runs:\n%s", strings.Join(runs, "\n")) }
+    if !strings.Contains(output.String(), "Usage: git remote <command>") { t.Errorf("Help should be printed, but was %q", output.String()) }
+    if !strings.Contains(screen.String(), "git> ") || !strings.Contains(screen.String(), "^C") {
+        t.Errorf("Unexpected screen: %q", screen.String())
+    }
+    if len(shell.History) != 3 { t.Errorf("History should have 3 lines, but was %q", shell.History) }
+}
+
+func TestShellCompletion(t *testing.T) {
+
+    // Test setup
+
+    var runs []string
+    root, _ := newShellCommand(&runs)
+    var screen bytes.Buffer
+    shell := &Shell{
+        Command: root,
+        In: strings.NewReader("rem\tad\t--tr\tdev origin url\rcheckout ma\t\tst\t\r"),
+        Out: &screen,
+        Edit: EditAlways,
+    }
+
+    // Test execution
+
+    err := shell.Run(context.Background())
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    expected := []string { `add "origin" "url" fetch=false track="dev"`, "checkout master" }
+    if !reflect.DeepEqual(runs, expected) { t.Errorf("Unexpected runs:\n%s", strings.Join(runs, "\n")) }
+    if !strings.Contains(screen.String(), "\nmain  master\n") { t.Errorf("Completions should be listed: %q", screen.String()) }
+}
+
+func TestShellMultiLine(t *testing.T) {
+
+    // Test setup
+
+    var runs []string
+    root, _ := newShellCommand(&runs)
+    var screen bytes.Buffer
+    shell := &Shell{
+        Command: root,
+        In: strings.NewReader("remote add \"multi\nline\" \\\n  url\n"),
+        Out: &screen,
+        Edit: EditAlways,
+        ContinuationPrompt: "... ",
+    }
+
+    // Test execution
+
+    err := shell.Run(context.Background())
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if !reflect.DeepEqual(runs, []string { `add "multi\nline" "url" fetch=false track=""` }) {
+        t.Errorf("Unexpected runs: %q", runs)
+    }
+    if strings.Count(screen.String(), "\r... ") < 2 { t.Errorf("Continuation prompts should be shown: %q", screen.String()) }
+}
+
+func TestShellScript(t *testing.T) {
+
+    // Test setup
+
+    var runs []string
+    root, output := newShellCommand(&runs)
+    var screen bytes.Buffer
+    shell := &Shell{
+        Command: root,
+        In: strings.NewReader("# Set up the remotes\nremote add --track=main a b\ncheckout nope\nremote add c d\nexit\nremote add e f\n"),
+        Out: &screen,
+        Edit: EditNever,
+    }
+
+    // Test execution
+
+    err := shell.Run(context.Background())
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    expected := []string { `add "a" "b" fetch=false track="main"`, `add "c" "d" fetch=false track=""` }
+    if !reflect.DeepEqual(runs, expected) { t.Errorf("Options should be reset between lines, but runs were:\n%s\n%s", strings.Join(runs, "\n"), output) }
+    if !strings.Contains(output.String(), `Error: Invalid choice "nope"`) { t.Errorf("Error should be printed: %q", output.String()) }
+    if screen.Len() != 0 { t.Errorf("Prompts should not be written to scripts: %q", screen.String()) }
+}
+
+func TestShellHistoryFile(t *testing.T) {
+
+    // Test setup
+
+    dir, err := ioutil.TempDir("", "shell")
+    if err != nil { t.Fatal(err) }
+    historyFile := filepath.Join(dir, "history")
+    ioutil.WriteFile(historyFile, []byte("checkout dev\n"), 0600)
+    var runs []string
+    root, _ := newShellCommand(&runs)
+    shell := &Shell{ Command: root, In: strings.NewReader("\x10\r"), Out: ioutil.Discard, Edit: EditAlways, HistoryFile: historyFile }
+
+    // Test execution
+
+    err = shell.Run(context.Background())
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if !reflect.DeepEqual(runs, []string { "checkout dev" }) { t.Errorf("History should be loaded, but runs were %q", runs) }
+    if content, _ := ioutil.ReadFile(historyFile); string(content) != "checkout dev\n" {
+        t.Errorf("Repeated line should not be appended to the history file: %q", content)
+    }
+}
+
+func TestCommandComplete(t *testing.T) {
+
+    // Test setup
+
+    var runs []string
+    root, _ := newShellCommand(&runs)
+
+    cases := []struct {
+        args []string
+        partial string
+        expected []string
+    }{
+        { nil, "", []string { "remote", "checkout" } },
+        { []string { "remote" }, "a", []string { "add" } },
+        { []string { "remote", "add" }, "--", []string { "--fetch", "--track" } },
+        { []string { "checkout" }, "d", []string { "dev" } },
+        { []string { "checkout", "dev" }, "", nil },
+        { []string { "remote", "add", "-t" }, "x", nil },
+    }
+    for _, c := range cases {
+
+        // Test execution
+
+        completions := root.Complete(c.args, c.partial)
+
+        // Assertions
+
+        if !reflect.DeepEqual(completions, c.expected) {
+            t.Errorf("%q %q: completions should be %q, but were %q", c.args, c.partial, c.expected, completions)
+        }
+    }
+}
@@ -0,0 +1,166 @@
+package command
+
+import (
+    "errors"
+    "strings"
+    "unicode/utf8"
+)
+
+// ErrIncomplete is returned by SplitLine when the line ends inside quotes or with a backslash, so
+// that the command continues on the next line.
+var ErrIncomplete = errors.New("Incomplete command line")
+
+// word is an argument scanned from a command line, and the index in the line where it starts
+type word struct {
+    text string
+    start int
+}
+
+func isBlank(c byte) bool {
+    return c == ' ' || c == '\t' || c == '\n'
+}
+
+// scanWords splits the line into words. open is true if the line does not end with a blank, so
+// that the last word may continue. If the line is incomplete, the words are returned with
+// ErrIncomplete, and the last word is the unterminated one.
+func scanWords(line string) (words []word, open bool, err error) {
+    var text strings.Builder
+    inWord, start := false, 0
+    endWord := func() {
+        words = append(words, word{ text.String(), start })
+        text.Reset()
+        inWord = false
+    }
+    for i := 0; i < len(line); {
+        c := line[i]
+        if !inWord {
+            if isBlank(c) {
+                i++
+                continue
+            }
+            if c == '\\' && i + 1 < len(line) && line[i + 1] == '\n' {
+                // A line continuation between words
+                i += 2
+                continue
+            }
+            if c == '#' {
+                // A comment until the end of the line
+                for i < len(line) && line[i] != '\n' { i++ }
+                continue
+            }
+            inWord, start = true, i
+        }
+        switch {
+        case isBlank(c):
+            endWord()
+            i++
+        case c == '\\':
+            if i + 1 == len(line) {
+                endWord()
+                return words, true, ErrIncomplete
+            }
+            if line[i + 1] == '\n' {
+                // A line continuation inside a word
+                i += 2
+                continue
+            }
+            _, size := utf8.DecodeRuneInString(line[i + 1:])
+            text.WriteString(line[i + 1:i + 1 + size])
+            i += 1 + size
+        case c == '\'':
+            end := strings.IndexByte(line[i + 1:], '\'')
+            if end < 0 {
+                text.WriteString(line[i + 1:])
+                endWord()
+                return words, true, ErrIncomplete
+            }
+            text.WriteString(line[i + 1:i + 1 + end])
+            i += end + 2
+        case c == '"':
+            i++
+            for ; i < len(line) && line[i] != '"'; i++ {
+                // In double quotes, backslash only escapes these characters
+                if line[i] == '\\' && i + 1 < len(line) && strings.IndexByte("\"\\$`\n", line[i + 1]) >= 0 {
+                    i++
+                    if line[i] == '\n' { continue }
+                }
+                text.WriteByte(line[i])
+            }
+            if i == len(line) {
+                endWord()
+                return words, true, ErrIncomplete
+            }
+            i++
+        default:
+            text.WriteByte(c)
+            i++
+        }
+    }
+    if inWord {
+        endWord()
+        return words, true, nil
+    }
+    return words, false, nil
+}
+
+// SplitLine splits a command line into arguments like a POSIX shell does, but without any
+// expansions. Arguments are separated by blanks, and can be quoted with single quotes, double
+// quotes or backslashes. A "#" at the start of an argument starts a comment until the end of the
+// line. If the line ends inside quotes or with a backslash, ErrIncomplete is returned, and the
+// line should be joined with the next one using "\n".
+func SplitLine(line string) ([]string, error) {
+    words, _, err := scanWords(line)
+    if err != nil { return nil, err }
+    args := make([]string, len(words))
+    for i, w := range words {
+        args[i] = w.text
+    }
+    return args, nil
+}
+
+// JoinArgs quotes the arguments and joins them with spaces into a command line, the reverse of
+// SplitLine. The result can also be passed to a POSIX shell, e.g. to print a command that can be
+// copied into a terminal, like the argv returned by options.OptionSet.MarshalArgs.
+func JoinArgs(args []string) string {
+    quoted := make([]string, len(args))
+    for i, arg := range args {
+        quoted[i] = quoteArg(arg)
+    }
+    return strings.Join(quoted, " ")
+}
+
+// splitCompletion splits the line before the cursor into the complete arguments and the partial
+// argument being typed, which starts at the index start of the line.
+func splitCompletion(line string) (args []string, partial string, start int) {
+    words, open, _ := scanWords(line)
+    if open {
+        last := words[len(words) - 1]
+        words, partial, start = words[:len(words) - 1], last.text, last.start
+    } else {
+        start = len(line)
+    }
+    for _, w := range words {
+        args = append(args, w.text)
+    }
+    return args, partial, start
+}
+
+// quoteArg quotes the argument with backslashes if needed, so that SplitLine returns it unchanged.
+// Since a backslash before a newline is a line continuation, arguments with newlines are quoted
+// with single quotes instead.
+func quoteArg(arg string) string {
+    if arg == "" {
+        return "''"
+    }
+    if strings.Contains(arg, "\n") {
+        return "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+    }
+    var quoted strings.Builder
+    for i := 0; i < len(arg); i++ {
+        if strings.IndexByte(" \t\\'\"#$`&|;<>()*?[]{}~!", arg[i]) >= 0 {
+            quoted.WriteByte('\\')
+        }
+        quoted.WriteByte(arg[i])
+    }
+    return quoted.String()
+}
@@ -0,0 +1,74 @@
+package command
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestSplitLine(t *testing.T) {
+    cases := map[string][]string {
+        ``: nil,
+        `  remote add  origin `: { "remote", "add", "origin" },
+        `echo 'a b' "c \"d\" \$e" f\ g`: { "echo", "a b", `c "d" $e`, "f g" },
+        `echo "it's" '\n'`: { "echo", "it's", `\n` },
+        `echo a#b # comment`: { "echo", "a#b" },
+        "echo a\\\nb \\\n c": { "echo", "ab", "c" },
+        "echo \"a\nb\"": { "echo", "a\nb" },
+        `echo '' ""`: { "echo", "", "" },
+        `echo ünï\cödé`: { "echo", "ünïcödé" },
+    }
+    for line, expected := range cases {
+        args, err := SplitLine(line)
+        if err != nil { t.Errorf("%q: error is not nil: %v", line, err) }
+        if len(args) != 0 || len(expected) != 0 {
+            if !reflect.DeepEqual(args, expected) { t.Errorf("%q: args should be %q, but were %q", line, expected, args) }
+        }
+    }
+}
+
+func TestSplitLineIncomplete(t *testing.T) {
+    for _, line := range []string { `echo 'a`, `echo "a`, `echo a\`, `echo "a\"` } {
+        if _, err := SplitLine(line); err != ErrIncomplete {
+            t.Errorf("%q: error should be ErrIncomplete, but was %v", line, err)
+        }
+    }
+}
+
+func TestSplitCompletion(t *testing.T) {
+    cases := []struct {
+        line string
+        args []string
+        partial string
+        start int
+    }{
+        { "remote ad", []string { "remote" }, "ad", 7 },
+        { "remote ", []string { "remote" }, "", 7 },
+        { `cat "my fi`, []string { "cat" }, "my fi", 4 },
+        { "", nil, "", 0 },
+    }
+    for _, c := range cases {
+        args, partial, start := splitCompletion(c.line)
+        if !reflect.DeepEqual(args, c.args) || partial != c.partial || start != c.start {
+            t.Errorf("%q: should be %q %q %d, but was %q %q %d", c.line, c.args, c.partial, c.start, args, partial, start)
+        }
+    }
+}
+
+func TestQuoteArg(t *testing.T) {
+    for _, arg := range []string { "plain", "a b", `it's "quoted"`, "", "a\nb", `back\slash`, "#hash" } {
+        quoted := quoteArg(arg)
+        args, err := SplitLine(quoted)
+        if err != nil || len(args) != 1 || args[0] != arg {
+            t.Errorf("%q quoted as %s was split into %q, %v", arg, quoted, args, err)
+        }
+    }
+}
+
+func TestJoinArgs(t *testing.T) {
+    argv := []string { "sed", "--expression=s/a b/c/", "", "it's", "a\nb", "-" }
+    line := JoinArgs(argv)
+    if line != `sed --expression=s/a\ b/c/ '' it\'s 'a` + "\n" + `b' -` { t.Errorf("Unexpected line: %s", line) }
+    if args, err := SplitLine(line); err != nil || !reflect.DeepEqual(args, argv) {
+        t.Errorf("%s was split into %q, %v", line, args, err)
+    }
+}
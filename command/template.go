@@ -0,0 +1,129 @@
+package command
+
+import (
+    "io"
+    "strings"
+    "text/template"
+    "unicode/utf8"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+// maxNameColumn is the maximum width of the name column of the help. Longer names are followed by
+// their description on the next line.
+const maxNameColumn = 30
+
+// DefaultHelpTemplate is the template of the standard help output. It can be used as a starting
+// point for a custom HelpTemplate.
+const DefaultHelpTemplate = `{{ heading (tr "help.usage") }} {{ .Usage }}
+{{- with .Description }}
+
+{{ wrap $.Width . }}
+{{- end }}
+{{- range .Sections }}
+
+{{ heading .Heading }}
+{{- $column := column .Entries }}
+{{- range .Entries }}
+{{ entry $column . }}
+{{- end }}
+{{- end }}
+`
+
+// HelpContext is the data that a help template is executed with.
+type HelpContext struct {
+    HelpData
+    Command *Command
+    // Width is the number of columns to wrap the output to
+    Width int
+}
+
+// helpTemplate returns the HelpTemplate of the command, or of its closest ancestor that has one
+func (c *Command) helpTemplate() string {
+    for cmd := c; cmd != nil; cmd = cmd.parent {
+        if cmd.HelpTemplate != "" {
+            return cmd.HelpTemplate
+        }
+    }
+    return DefaultHelpTemplate
+}
+
+// templateFuncs returns the helper functions available to help templates:
+//
+//   wrap WIDTH TEXT        wraps the text to the width, see Wrap
+//   indent SPACES TEXT     indents every line of the text, see Indent
+//   pad WIDTH TEXT         pads the text with spaces to the width, see Pad
+//   synopsis PARTS...      joins the non-empty parts of a synopsis with spaces
+//   tr ID ARGS...          translates the message with the i18n.MessageID
+//   heading TEXT           styles the text with the Heading style of the theme
+//   name TEXT              styles the text with the Name style of the theme
+//   error TEXT             styles the text with the Error style of the theme
+//   column ENTRIES         returns the width of the name column of the entries
+//   entry COLUMN ENTRY     formats the entry with its name in a column of the width
+func (r *Renderer) templateFuncs(w io.Writer) template.FuncMap {
+    width, color, theme := r.width(w), r.colored(w), r.theme()
+    return template.FuncMap {
+        "wrap": func(width int, text string) string { return Wrap(text, width) },
+        "indent": func(spaces int, text string) string { return Indent(text, spaces) },
+        "pad": func(width int, text string) string { return Pad(text, width) },
+        "synopsis": func(parts ...string) string {
+            var nonEmpty []string
+            for _, part := range parts {
+                if part != "" { nonEmpty = append(nonEmpty, part) }
+            }
+            return strings.Join(nonEmpty, " ")
+        },
+        "tr": func(id string, a ...interface{}) string { return i18n.Sprintf(i18n.MessageID(id), a...) },
+        "heading": func(text string) string { return theme.Heading.Render(text, color) },
+        "name": func(text string) string { return theme.Name.Render(text, color) },
+        "error": func(text string) string { return theme.Error.Render(text, color) },
+        "column": nameColumn,
+        "entry": func(column int, entry HelpEntry) string {
+            return formatEntry(entry, column, width, theme.Name, color)
+        },
+    }
+}
+
+// nameColumn returns the width of the longest name of the entries, up to maxNameColumn
+func nameColumn(entries []HelpEntry) int {
+    column := 0
+    for _, entry := range entries {
+        if n := utf8.RuneCountInString(entry.Name); n > column && n <= maxNameColumn {
+            column = n
+        }
+    }
+    return column
+}
+
+// formatEntry formats the entry as "  name  description", with the description wrapped and aligned
+// after the name column. If the name is longer than the column, the description starts on the
+// next line.
+func formatEntry(entry HelpEntry, column int, width int, style Style, color bool) string {
+    name := style.Render(entry.Name, color)
+    if entry.Description == "" {
+        return "  " + name
+    }
+    // The description starts after 2 spaces of indentation, the name column and 2 spaces
+    indent := column + 4
+    description := Indent(Wrap(entry.Description, width - indent), indent)
+    nameWidth := utf8.RuneCountInString(entry.Name)
+    if nameWidth > column {
+        return "  " + name + "\n" + description
+    }
+    return "  " + name + strings.Repeat(" ", column - nameWidth) + "  " + strings.TrimLeft(description, " ")
+}
+
+// RenderHelpTemplate executes the help template text with the context and writes the result to
+// w. The Width of the context is set to the width of the Renderer.
+func (r *Renderer) RenderHelpTemplate(w io.Writer, text string, context HelpContext) error {
+    tmpl, err := template.New("help").Funcs(r.templateFuncs(w)).Parse(text)
+    if err != nil { return err }
+    context.Width = r.width(w)
+    return tmpl.Execute(w, context)
+}
+
+// RenderHelp writes the help to w using the DefaultHelpTemplate.
+func (r *Renderer) RenderHelp(w io.Writer, data HelpData) {
+    // The default template is known to be valid
+    r.RenderHelpTemplate(w, DefaultHelpTemplate, HelpContext{ HelpData: data })
+}
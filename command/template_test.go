@@ -0,0 +1,57 @@
+package command
+
+import (
+    "bytes"
+    "testing"
+)
+
+func TestCustomHelpTemplate(t *testing.T) {
+    // Setup
+    remote := newRemoteAdd()
+    remote.HelpTemplate = `{{ synopsis "usage:" .Command.Path "" "..." }}
+{{ range .Sections }}{{ .Heading }} {{ len .Entries }}
+{{ end }}{{ pad 8 "x" }}|{{ indent 2 (wrap 10 "custom help template") }}
+`
+
+    // Execution
+    var buf bytes.Buffer
+    err := remote.Lookup("add").PrintHelp(&buf)
+
+    // Assertions
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    expected := `usage: remote add ...
+Arguments: 1
+Location: 1
+Options: 2
+x       |  custom
+  help
+  template
+`
+    if buf.String() != expected {
+        t.Errorf("Unexpected help:\n%s", buf.String())
+    }
+}
+
+func TestSubcommandHelpTemplate(t *testing.T) {
+    // Setup
+    remote := newRemoteAdd()
+    remote.HelpTemplate = "{{ .Usage }}\n"
+    remote.Lookup("remove").HelpTemplate = "{{ tr \"help.usage\" }} {{ name .Command.Name }}\n"
+
+    // Execution
+    var add, remove bytes.Buffer
+    remote.Lookup("add").PrintHelp(&add)
+    remote.Lookup("rm").PrintHelp(&remove)
+
+    // Assertions
+    if add.String() != "remote add [OPTION]... <name> <url>\n" { t.Errorf("Unexpected help: %s", add.String()) }
+    if remove.String() != "Usage: remove\n" { t.Errorf("Unexpected help: %s", remove.String()) }
+}
+
+func TestInvalidHelpTemplate(t *testing.T) {
+    remote := newRemoteAdd()
+    remote.HelpTemplate = "{{ .Usage "
+
+    var buf bytes.Buffer
+    if err := remote.PrintHelp(&buf); err == nil { t.Errorf("Error should not be nil") }
+}
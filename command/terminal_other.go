@@ -0,0 +1,20 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package command
+
+import (
+    "errors"
+    "os"
+)
+
+// terminalSize returns the number of columns of the terminal f, and whether f is a terminal. On
+// this platform, files are never detected as terminals.
+func terminalSize(f *os.File) (int, bool) {
+    return 0, false
+}
+
+// makeRaw is not supported on this platform.
+func makeRaw(f *os.File) (restore func() error, err error) {
+    return nil, errors.New("Raw mode is not supported on this platform")
+}
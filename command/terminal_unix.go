@@ -0,0 +1,49 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+// +build linux darwin dragonfly freebsd netbsd openbsd
+
+package command
+
+import (
+    "os"
+    "syscall"
+    "unsafe"
+)
+
+type winsize struct {
+    rows, cols, xpixel, ypixel uint16
+}
+
+// terminalSize returns the number of columns of the terminal f, and whether f is a terminal
+func terminalSize(f *os.File) (int, bool) {
+    var ws winsize
+    _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+    if errno != 0 {
+        return 0, false
+    }
+    return int(ws.cols), true
+}
+
+func ioctlTermios(f *os.File, request uintptr, termios *syscall.Termios) error {
+    _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), request, uintptr(unsafe.Pointer(termios)))
+    if errno != 0 {
+        return errno
+    }
+    return nil
+}
+
+// makeRaw puts the terminal f into raw mode, where input is available byte by byte without echo
+// or signals, and returns a function that restores the previous mode. Output processing is kept,
+// so "\n" still starts a new line.
+func makeRaw(f *os.File) (restore func() error, err error) {
+    var old syscall.Termios
+    if err := ioctlTermios(f, ioctlGetTermios, &old); err != nil { return nil, err }
+    raw := old
+    raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+    raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+    raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+    raw.Cflag |= syscall.CS8
+    raw.Cc[syscall.VMIN] = 1
+    raw.Cc[syscall.VTIME] = 0
+    if err := ioctlTermios(f, ioctlSetTermios, &raw); err != nil { return nil, err }
+    return func() error { return ioctlTermios(f, ioctlSetTermios, &old) }, nil
+}
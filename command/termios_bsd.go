@@ -0,0 +1,12 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+// +build darwin dragonfly freebsd netbsd openbsd
+
+package command
+
+import "syscall"
+
+// The ioctl requests to get and set the termios of a terminal
+const (
+    ioctlGetTermios = syscall.TIOCGETA
+    ioctlSetTermios = syscall.TIOCSETA
+)
@@ -0,0 +1,9 @@
+package command
+
+import "syscall"
+
+// The ioctl requests to get and set the termios of a terminal
+const (
+    ioctlGetTermios = syscall.TCGETS
+    ioctlSetTermios = syscall.TCSETS
+)
@@ -4,30 +4,22 @@ import (
     "fmt"
     "os"
     "os/exec"
+    "regexp"
 
     "github.com/mauricelam/gocmdln/params"
 )
 
-type separatedParamSpec struct {
+// separatorParamSpec captures an optional "--" separator between the commits and the paths
+type separatorParamSpec struct {
     params.ParamSpec
 }
 
-func (spec *separatedParamSpec) CaptureLength(argvSlice []string) (int, error) {
-    for i, arg := range argvSlice {
-        if arg == "--" {
-            argvSlice = argvSlice[:i+1]
-            break
-        }
-    }
-    return spec.ParamSpec.CaptureLength(argvSlice)
+func (spec *separatorParamSpec) Synopsis() string {
+    return "[--]"
 }
 
-func (spec *separatedParamSpec) Set(args []string) error {
-    if l := len(args); l > 0 && args[l-1] == "--" {
-        args = args[:l-1]
-    }
-    return spec.ParamSpec.Set(args)
-}
+// revisionPattern matches the syntax of revisions, like "main", "v1.0", "a1b2c3d" or "HEAD~2^"
+var revisionPattern = regexp.MustCompile(`^[\w.@/][\w./@{}~^-]*$`)
 
 /**
  git diff [options] [<commit>] [--] [<path>…​]
@@ -37,18 +29,31 @@ func (spec *separatedParamSpec) Set(args []string) error {
  git diff [options] [--no-index] [--] <path> <path>
  */
 func main() {
-    var commitsOrPaths params.StringValueList
-    params.Param(&separatedParamSpec{ params.NewListParamSpec(&commitsOrPaths, "commits", true, nil) })
-    paths := params.StringList("paths", true, nil)
+    // Like git, an argument before "--" is a revision unless it names an existing path, so branch
+    // and tag names are accepted as well as commit hashes and expressions like "HEAD~2"
+    var commits params.StringValueList
+    params.VarMatching(&commits, "commit", func(arg string) bool {
+        if !revisionPattern.MatchString(arg) {
+            return false
+        }
+        _, err := os.Stat(arg)
+        return os.IsNotExist(err)
+    }, 0, -1, nil)
+    var separator params.StringValueList
+    params.Param(&separatorParamSpec{
+        params.NewPatternParamSpec(&separator, "--", func(arg string) bool { return arg == "--" }, 0, 1, nil),
+    })
+    paths := params.StringList("path", true, nil)
 
     err := params.Parse(os.Args[1:])
     if err != nil {
         println(err.Error())
+        println("Usage: git-diff " + params.Synopsis())
         return
     }
 
     args := []string { "diff" }
-    args = append(args, commitsOrPaths...)
+    args = append(args, commits...)
     if *paths != nil {
         args = append(args, "--")
         args = append(args, *paths...)
@@ -1,11 +1,12 @@
 package main
 
 import (
-    "flag"
     "fmt"
     "os"
     "os/exec"
 
+    "github.com/mauricelam/gocmdln/command"
+    "github.com/mauricelam/gocmdln/options"
     "github.com/mauricelam/gocmdln/params"
 )
 
@@ -42,41 +43,46 @@ import (
  */
 
 func main() {
-    command := params.String("command", true, nil)
-    inputFiles := params.StringList("inputFiles", true, nil)
+    params.String("command", true, params.Help{ Short: "the script to run, if no -e or -f option is given" })
+    params.InputFileList("inputFiles", /* lazy */ true, true, params.Help{ Placeholder: "input-file" })
 
-    quiet := flag.Bool("quiet", false, "suppress automatic printing of pattern space")
-    script := flag.String("e", "", "add the script to the commands to be executed")
-    scriptFile := flag.String("f", "", "add the contents of script-file to the commands to be executed")
-    followSymlinks := flag.Bool("follow-symlinks", false, "follow symlinks when processing in place; hard links will still be broken.")
-    inplace := flag.Bool("i", false, "edit files in place (makes backup if extension supplied). The default operation mode is to break symbolic and hard links. This can be changed with --follow-symlinks and --copy.")
-    copyFlag := flag.Bool("copy", false, "use copy instead of rename when shuffling files in -i mode. While this will avoid breaking links (symbolic or hard), the resulting editing operation is not atomic. This is rarely the desired mode; --follow-symlinks is usually enough, and it is both faster and more secure.")
-    lineLength := flag.Int("line-length", 0, "specify the desired line-wrap length for the 'l' command")
-    posix := flag.Bool("posix", false, "disable all GNU extensions")
-    regexpExtended := flag.Bool("regexp-extended", false, "use extended regular expressions in the script.")
-    separate := flag.Bool("separate", false, "consider files as separate rather than as a single continuous long stream.")
-    unbuffered := flag.Bool("unbuffered", false, "load minimal amounts of data from the input files and flush the output buffers more often")
+    // Allow options after the script, like "sed script.sed -n file"
+    opts := &options.OptionSet{ Mode: options.Intermixed }
+    opts.Bool("quiet", 'n', "suppress automatic printing of pattern space")
+    opts.Lookup("quiet").Alias("silent")
+    opts.String("expression", 'e', "", "add the script to the commands to be executed")
+    opts.Lookup("expression").Placeholder = "script"
+    opts.String("file", 'f', "", "add the contents of script-file to the commands to be executed")
+    opts.Lookup("file").Placeholder = "script-file"
+    opts.Bool("follow-symlinks", 0, "follow symlinks when processing in place; hard links will still be broken.")
+    opts.OptionalString("in-place", 'i', "", "", "edit files in place (makes backup if extension supplied). The default operation mode is to break symbolic and hard links. This can be changed with --follow-symlinks and --copy.")
+    opts.Lookup("in-place").Placeholder = "SUFFIX"
+    opts.Bool("copy", 'c', "use copy instead of rename when shuffling files in -i mode. While this will avoid breaking links (symbolic or hard), the resulting editing operation is not atomic. This is rarely the desired mode; --follow-symlinks is usually enough, and it is both faster and more secure.")
+    opts.Int("line-length", 'l', 0, "specify the desired line-wrap length for the 'l' command")
+    opts.Lookup("line-length").Placeholder = "N"
+    opts.Bool("posix", 0, "disable all GNU extensions")
+    opts.Bool("regexp-extended", 'r', "use extended regular expressions in the script.")
+    opts.Bool("separate", 's', "consider files as separate rather than as a single continuous long stream.")
+    opts.Bool("unbuffered", 'u', "load minimal amounts of data from the input files and flush the output buffers more often")
+    help := opts.Bool("help", 0, "display this help and exit")
 
-    flag.Parse()
-    params.Parse(flag.Args())
+    err := opts.ParseParams(os.Args[1:], params.DefaultParamSet())
+    if err != nil || *help {
+        if err != nil {
+            os.Stderr.WriteString(err.Error() + "\n")
+        }
+        options.PrintUsage(os.Stderr, "sed", opts, params.DefaultParamSet())
+        os.Exit(2)
+    }
 
-    // Reconstruct the command flags, just because
-    args := []string{}
-    if *quiet { args = append(args, "--quiet") }
-    if *script != "" { args = append(args, "-e", *script) }
-    if *scriptFile != "" { args = append(args, "-f", *scriptFile) }
-    if *followSymlinks { args = append(args, "-follow-symlinks") }
-    if *inplace { args = append(args, "-i") }
-    if *copyFlag { args = append(args, "-copy") }
-    if *lineLength != 0 { args = append(args, "-line-length", string(*lineLength)) }
-    if *posix { args = append(args, "-posix") }
-    if *regexpExtended { args = append(args, "-regexp-extended") }
-    if *separate { args = append(args, "-separate") }
-    if *unbuffered { args = append(args, "-unbuffered") }
-    args = append(args, *command)
-    args = append(args, *inputFiles...)
+    // Reconstruct the arguments from the parsed values, to pass them on to the real sed
+    args, err := opts.MarshalArgs(params.DefaultParamSet())
+    if err != nil {
+        os.Stderr.WriteString(err.Error() + "\n")
+        os.Exit(2)
+    }
 
-    fmt.Println(args)
+    fmt.Println(command.JoinArgs(args))
     output, err := exec.Command("sed", args...).CombinedOutput()
     if err != nil {
       os.Stderr.WriteString(err.Error())
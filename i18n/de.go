@@ -0,0 +1,58 @@
+package i18n
+
+func init() {
+    Register(&Catalog{
+        Locale: "de",
+        Plural: OneOther,
+        Messages: map[MessageID][]string {
+            MissingArgument: { `Erforderliches Argument "%s" fehlt` },
+            TooManyArguments: { `Zu viele Argumente. %d Argument übrig`, `Zu viele Argumente. %d Argumente übrig` },
+            CapturedLessThanMin: { `Argument "%s" hat weniger Werte als erforderlich erhalten` },
+            InvalidValue: { `Ungültiger Wert "%s" für Argument "%s"` },
+            InvalidChoice: { `Ungültige Auswahl "%s", erwartet wird eine von %s` },
+            DidYouMean: { `Meinten Sie %s?` },
+            ListOr: { `%s oder %s` },
+            DeprecatedArgument: { `Warnung: Argument "%s" ist veraltet: %s` },
+            RenamedArgument: { `Warnung: Argument "%s" wurde in "%s" umbenannt` },
+            Warning: { `Warnung: %s` },
+            MultipleValues: { `Mehrere Werte %v können nicht gesetzt werden` },
+            DuplicateKey: { `Doppelter Schlüssel "%s"` },
+            ExpectedKeyValue: { `SCHLÜSSEL=WERT erwartet, aber "%s" erhalten` },
+            GroupSize: { `Gruppen von %d Werten erwartet, aber %d Werte erhalten` },
+            GroupSlot: { `Gruppe %d, Feld "%s": %w` },
+            IsDirectory: { `"%s" ist ein Verzeichnis` },
+            NotDirectory: { `"%s" ist kein Verzeichnis` },
+            MissingDirectory: { `Das Verzeichnis von "%s" existiert nicht` },
+            InvalidPattern: { `Ungültiges Muster "%s": %v` },
+            NoMatches: { `Keine Treffer für das Muster "%s"` },
+            InvalidIP: { `Ungültige IP-Adresse "%s"` },
+            InvalidByteSize: { `Ungültige Bytegröße "%s"` },
+            ByteSizeOutOfRange: { `Bytegröße "%s" außerhalb des gültigen Bereichs` },
+            InvalidFileMode: { `Ungültiger Dateimodus "%s"` },
+            InvalidInteger: { `Ungültige Ganzzahl "%s"` },
+            InvalidNumber: { `Ungültige Zahl "%s"` },
+            ArgumentErrorPrefix: { `Argument "%s": %w` },
+
+            UnknownOption: { `Unbekannte Option "%s"` },
+            AmbiguousOption: { `Mehrdeutige Option "%s", möglich sind %s` },
+            OptionNoArgument: { `Option %s akzeptiert kein Argument` },
+            OptionRequiresArgument: { `Option %s erfordert ein Argument` },
+            InvalidOptionArgument: { `Ungültiges Argument "%s" für Option %s: %v` },
+            OptionNotTerminated: { `Option %s muss mit "%s" abgeschlossen werden` },
+            UnusedOptionArgument: { `Argument "%s" wurde von Option %s nicht verwendet` },
+            OptionParamsError: { `Option %s: %v` },
+
+            UnknownCommand: { `Unbekannter Befehl "%s" für "%s"` },
+            MissingCommand: { `Befehl für "%s" fehlt, erwartet wird einer von %s` },
+
+            UsageHeading: { `Verwendung:` },
+            ArgumentsHeading: { `Argumente:` },
+            CommandsHeading: { `Befehle:` },
+            OptionsHeading: { `Optionen:` },
+            ExamplesLabel: { `Beispiele:` },
+            EnvironmentLabel: { `Umgebung:` },
+            ErrorLabel: { `Fehler:` },
+            DeprecatedLabel: { `Veraltet:` },
+        },
+    })
+}
@@ -0,0 +1,60 @@
+package i18n
+
+var english = &Catalog{
+    Locale: "en",
+    Plural: OneOther,
+    Messages: map[MessageID][]string {
+        MissingArgument: { `Missing required argument "%s"` },
+        TooManyArguments: { `Too many arguments. %d remaining`, `Too many arguments. %d remaining` },
+        CapturedLessThanMin: { `Argument "%s" captured less than min length` },
+        InvalidValue: { `Invalid value "%s" for argument "%s"` },
+        InvalidChoice: { `Invalid choice "%s", expected one of %s` },
+        DidYouMean: { `Did you mean %s?` },
+        ListOr: { `%s or %s` },
+        DeprecatedArgument: { `Warning: argument "%s" is deprecated: %s` },
+        RenamedArgument: { `Warning: argument "%s" was renamed to "%s"` },
+        Warning: { `Warning: %s` },
+        MultipleValues: { `Cannot set multiple values %v` },
+        DuplicateKey: { `Duplicate key "%s"` },
+        ExpectedKeyValue: { `Expected KEY=VALUE, but was "%s"` },
+        GroupSize: { `Expected groups of %d values, but got %d values` },
+        GroupSlot: { `Group %d, slot "%s": %w` },
+        IsDirectory: { `"%s" is a directory` },
+        NotDirectory: { `"%s" is not a directory` },
+        MissingDirectory: { `Directory of "%s" does not exist` },
+        InvalidPattern: { `Invalid pattern "%s": %v` },
+        NoMatches: { `No matches for pattern "%s"` },
+        InvalidIP: { `Invalid IP address "%s"` },
+        InvalidByteSize: { `Invalid byte size "%s"` },
+        ByteSizeOutOfRange: { `Byte size "%s" out of range` },
+        InvalidFileMode: { `Invalid file mode "%s"` },
+        InvalidInteger: { `Invalid integer "%s"` },
+        InvalidNumber: { `Invalid number "%s"` },
+        ArgumentErrorPrefix: { `Argument "%s": %w` },
+
+        UnknownOption: { `Unknown option "%s"` },
+        AmbiguousOption: { `Ambiguous option "%s" could be %s` },
+        OptionNoArgument: { `Option %s does not take an argument` },
+        OptionRequiresArgument: { `Option %s requires an argument` },
+        InvalidOptionArgument: { `Invalid argument "%s" for option %s: %v` },
+        OptionNotTerminated: { `Option %s must be terminated by "%s"` },
+        UnusedOptionArgument: { `Argument "%s" was not used by option %s` },
+        OptionParamsError: { `Option %s: %v` },
+
+        UnknownCommand: { `Unknown command "%s" for "%s"` },
+        MissingCommand: { `Missing command for "%s", expected one of %s` },
+
+        UsageHeading: { `Usage:` },
+        ArgumentsHeading: { `Arguments:` },
+        CommandsHeading: { `Commands:` },
+        OptionsHeading: { `Options:` },
+        ExamplesLabel: { `Examples:` },
+        EnvironmentLabel: { `Environment:` },
+        ErrorLabel: { `Error:` },
+        DeprecatedLabel: { `Deprecated:` },
+    },
+}
+
+func init() {
+    Register(english)
+}
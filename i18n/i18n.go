@@ -0,0 +1,207 @@
+// Package i18n translates the error messages and the help headings of the params, options and
+// command packages. Messages are identified by stable MessageIDs, and each locale has a Catalog of
+// translations, with support for plural forms. The locale is detected from the LC_ALL, LC_MESSAGES
+// and LANG environment variables, or set explicitly using SetLocale.
+package i18n
+
+import (
+    "errors"
+    "fmt"
+    "os"
+    "strings"
+    "sync"
+)
+
+// MessageID identifies a message independently of its translation. The IDs are stable, so they can
+// be used in catalogs maintained outside of this package.
+type MessageID string
+
+const (
+    MissingArgument MessageID = "params.missing-argument"
+    TooManyArguments MessageID = "params.too-many-arguments"
+    CapturedLessThanMin MessageID = "params.captured-less-than-min"
+    InvalidValue MessageID = "params.invalid-value"
+    InvalidChoice MessageID = "params.invalid-choice"
+    DidYouMean MessageID = "params.did-you-mean"
+    ListOr MessageID = "params.list-or"
+    DeprecatedArgument MessageID = "params.deprecated-argument"
+    RenamedArgument MessageID = "params.renamed-argument"
+    Warning MessageID = "params.warning"
+    MultipleValues MessageID = "params.multiple-values"
+    DuplicateKey MessageID = "params.duplicate-key"
+    ExpectedKeyValue MessageID = "params.expected-key-value"
+    GroupSize MessageID = "params.group-size"
+    GroupSlot MessageID = "params.group-slot"
+    IsDirectory MessageID = "params.is-directory"
+    NotDirectory MessageID = "params.not-directory"
+    MissingDirectory MessageID = "params.missing-directory"
+    InvalidPattern MessageID = "params.invalid-pattern"
+    NoMatches MessageID = "params.no-matches"
+    InvalidIP MessageID = "params.invalid-ip"
+    InvalidByteSize MessageID = "params.invalid-byte-size"
+    ByteSizeOutOfRange MessageID = "params.byte-size-out-of-range"
+    InvalidFileMode MessageID = "params.invalid-file-mode"
+    InvalidInteger MessageID = "params.invalid-integer"
+    InvalidNumber MessageID = "params.invalid-number"
+    ArgumentErrorPrefix MessageID = "params.argument-error"
+
+    UnknownOption MessageID = "options.unknown-option"
+    AmbiguousOption MessageID = "options.ambiguous-option"
+    OptionNoArgument MessageID = "options.no-argument"
+    OptionRequiresArgument MessageID = "options.requires-argument"
+    InvalidOptionArgument MessageID = "options.invalid-argument"
+    OptionNotTerminated MessageID = "options.not-terminated"
+    UnusedOptionArgument MessageID = "options.unused-argument"
+    OptionParamsError MessageID = "options.params-error"
+
+    UnknownCommand MessageID = "command.unknown-command"
+    MissingCommand MessageID = "command.missing-command"
+
+    UsageHeading MessageID = "help.usage"
+    ArgumentsHeading MessageID = "help.arguments"
+    CommandsHeading MessageID = "help.commands"
+    OptionsHeading MessageID = "help.options"
+    ExamplesLabel MessageID = "help.examples"
+    EnvironmentLabel MessageID = "help.environment"
+    ErrorLabel MessageID = "help.error"
+    DeprecatedLabel MessageID = "help.deprecated"
+)
+
+// Catalog is the set of translated messages of a locale.
+type Catalog struct {
+    // Locale is the language, e.g. "de", optionally followed by the territory, e.g. "pt_BR"
+    Locale string
+
+    // Plural returns the index of the plural form to use for the count n. If nil, the first form is
+    // always used.
+    Plural func(n int) int
+
+    // Messages maps the IDs to their translations, which are format strings as in fmt.Sprintf.
+    // Plural messages have a translation for each plural form, other messages have a single one.
+    // Messages missing from the catalog fall back to English.
+    Messages map[MessageID][]string
+}
+
+// mutex guards the catalogs and the locale, so that messages can be translated concurrently
+var mutex sync.Mutex
+
+var catalogs = map[string]*Catalog {}
+
+// Register adds a catalog, replacing any catalog previously registered for its locale.
+func Register(catalog *Catalog) {
+    mutex.Lock()
+    defer mutex.Unlock()
+    catalogs[catalog.Locale] = catalog
+}
+
+var locale string
+var localeSet bool
+
+// SetLocale sets the locale of the messages, e.g. "de_DE.UTF-8" or "ja". If locale is empty, the
+// locale is detected from the environment again.
+func SetLocale(l string) {
+    mutex.Lock()
+    defer mutex.Unlock()
+    locale = normalizeLocale(l)
+    localeSet = l != ""
+}
+
+// Locale returns the locale of the messages.
+func Locale() string {
+    mutex.Lock()
+    defer mutex.Unlock()
+    return currentLocale()
+}
+
+// currentLocale returns the locale, detecting it if it is not set. The mutex must be held.
+func currentLocale() string {
+    if !localeSet {
+        locale = DetectLocale()
+        localeSet = true
+    }
+    return locale
+}
+
+// DetectLocale returns the locale of messages in the environment, from the first of the LC_ALL,
+// LC_MESSAGES and LANG environment variables that is set, as in POSIX. The encoding and modifier
+// are removed, so "de_DE.UTF-8" becomes "de_DE".
+func DetectLocale() string {
+    for _, env := range []string { "LC_ALL", "LC_MESSAGES", "LANG" } {
+        if value := os.Getenv(env); value != "" {
+            return normalizeLocale(value)
+        }
+    }
+    return "en"
+}
+
+func normalizeLocale(l string) string {
+    if i := strings.IndexAny(l, ".@"); i >= 0 {
+        l = l[:i]
+    }
+    l = strings.Replace(l, "-", "_", -1)
+    if l == "" || l == "C" || l == "POSIX" {
+        return "en"
+    }
+    return l
+}
+
+// catalog returns the catalog of the locale, or of its language if there is no catalog for the
+// territory
+func catalog() *Catalog {
+    mutex.Lock()
+    defer mutex.Unlock()
+    l := currentLocale()
+    if c, ok := catalogs[l]; ok {
+        return c
+    }
+    if i := strings.Index(l, "_"); i >= 0 {
+        if c, ok := catalogs[l[:i]]; ok {
+            return c
+        }
+    }
+    return english
+}
+
+func translate(id MessageID, n int) string {
+    c := catalog()
+    forms, ok := c.Messages[id]
+    if !ok {
+        c = english
+        forms, ok = c.Messages[id]
+        if !ok { return string(id) }
+    }
+    form := 0
+    if c.Plural != nil { form = c.Plural(n) }
+    if form < 0 || form >= len(forms) { form = len(forms) - 1 }
+    return forms[form]
+}
+
+// Sprintf formats the message in the current locale with the arguments.
+func Sprintf(id MessageID, a ...interface{}) string {
+    return fmt.Sprintf(translate(id, 1), a...)
+}
+
+// Nsprintf formats the plural form of the message for the count n in the current locale with the
+// arguments. The count is not passed to the format automatically, so it is typically one of the
+// arguments too.
+func Nsprintf(id MessageID, n int, a ...interface{}) string {
+    return fmt.Sprintf(translate(id, n), a...)
+}
+
+// Errorf returns an error with the message formatted by Sprintf. As in fmt.Errorf, messages can
+// wrap an error argument with the %w verb.
+func Errorf(id MessageID, a ...interface{}) error {
+    return fmt.Errorf(translate(id, 1), a...)
+}
+
+// Nerrorf returns an error with the message formatted by Nsprintf.
+func Nerrorf(id MessageID, n int, a ...interface{}) error {
+    return errors.New(Nsprintf(id, n, a...))
+}
+
+// OneOther is the plural rule of languages with a singular and a plural form, like English and
+// German.
+func OneOther(n int) int {
+    if n == 1 { return 0 }
+    return 1
+}
@@ -0,0 +1,68 @@
+package i18n
+
+import (
+    "os"
+    "testing"
+)
+
+func setEnv(t *testing.T, env map[string]string) {
+    for _, name := range []string { "LC_ALL", "LC_MESSAGES", "LANG" } {
+        old, ok := os.LookupEnv(name)
+        value, set := env[name]
+        if set { os.Setenv(name, value) } else { os.Unsetenv(name) }
+        t.Cleanup(func() {
+            if ok { os.Setenv(name, old) } else { os.Unsetenv(name) }
+        })
+    }
+}
+
+func TestDetectLocale(t *testing.T) {
+    cases := []struct {
+        env map[string]string
+        locale string
+    }{
+        { map[string]string {}, "en" },
+        { map[string]string { "LANG": "de_DE.UTF-8" }, "de_DE" },
+        { map[string]string { "LANG": "de_DE.UTF-8", "LC_MESSAGES": "ja_JP.UTF-8" }, "ja_JP" },
+        { map[string]string { "LANG": "de_DE", "LC_MESSAGES": "ja_JP", "LC_ALL": "C" }, "en" },
+        { map[string]string { "LANG": "sr_RS@latin" }, "sr_RS" },
+    }
+    for _, c := range cases {
+        setEnv(t, c.env)
+        if locale := DetectLocale(); locale != c.locale {
+            t.Errorf("Locale for %v should be %s, but was %s", c.env, c.locale, locale)
+        }
+    }
+}
+
+func TestSprintf(t *testing.T) {
+    defer SetLocale("")
+
+    SetLocale("de_AT.UTF-8")
+    if s := Sprintf(MissingArgument, "file"); s != `Erforderliches Argument "file" fehlt` { t.Errorf("Unexpected message: %s", s) }
+    SetLocale("ja")
+    if s := Sprintf(InvalidValue, "x", "count"); s != `引数 "count" の値 "x" は無効です` { t.Errorf("Unexpected message: %s", s) }
+    SetLocale("fr_FR")
+    if s := Sprintf(MissingArgument, "file"); s != `Missing required argument "file"` { t.Errorf("Unexpected message: %s", s) }
+}
+
+func TestPlural(t *testing.T) {
+    defer SetLocale("")
+
+    SetLocale("de")
+    if s := Nsprintf(TooManyArguments, 1, 1); s != "Zu viele Argumente. 1 Argument übrig" { t.Errorf("Unexpected message: %s", s) }
+    if s := Nsprintf(TooManyArguments, 3, 3); s != "Zu viele Argumente. 3 Argumente übrig" { t.Errorf("Unexpected message: %s", s) }
+    SetLocale("ja")
+    if s := Nsprintf(TooManyArguments, 3, 3); s != "引数が多すぎます。3 個の引数が残っています" { t.Errorf("Unexpected message: %s", s) }
+}
+
+func TestRegisterAndFallback(t *testing.T) {
+    defer SetLocale("")
+    defer delete(catalogs, "xx")
+
+    Register(&Catalog{ Locale: "xx", Messages: map[MessageID][]string { UsageHeading: { "Xsage:" } } })
+    SetLocale("xx_YY")
+    if s := Sprintf(UsageHeading); s != "Xsage:" { t.Errorf("Unexpected message: %s", s) }
+    if s := Sprintf(OptionsHeading); s != "Options:" { t.Errorf("Unexpected message: %s", s) }
+    if s := Sprintf(MessageID("unknown")); s != "unknown" { t.Errorf("Unexpected message: %s", s) }
+}
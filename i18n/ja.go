@@ -0,0 +1,59 @@
+package i18n
+
+func init() {
+    Register(&Catalog{
+        Locale: "ja",
+        // Japanese nouns have no plural forms
+        Plural: nil,
+        Messages: map[MessageID][]string {
+            MissingArgument: { `必須の引数 "%s" がありません` },
+            TooManyArguments: { `引数が多すぎます。%d 個の引数が残っています` },
+            CapturedLessThanMin: { `引数 "%s" の値が最小数に足りません` },
+            InvalidValue: { `引数 "%[2]s" の値 "%[1]s" は無効です` },
+            InvalidChoice: { `選択肢 "%s" は無効です。次のいずれかを指定してください: %s` },
+            DidYouMean: { `もしかして: %s` },
+            ListOr: { `%s または %s` },
+            DeprecatedArgument: { `警告: 引数 "%s" は非推奨です: %s` },
+            RenamedArgument: { `警告: 引数 "%s" は "%s" に名前が変更されました` },
+            Warning: { `警告: %s` },
+            MultipleValues: { `複数の値 %v は設定できません` },
+            DuplicateKey: { `キー "%s" が重複しています` },
+            ExpectedKeyValue: { `KEY=VALUE の形式が必要ですが、"%s" が指定されました` },
+            GroupSize: { `%d 個ずつの値のグループが必要ですが、%d 個の値が指定されました` },
+            GroupSlot: { `グループ %d、スロット "%s": %w` },
+            IsDirectory: { `"%s" はディレクトリです` },
+            NotDirectory: { `"%s" はディレクトリではありません` },
+            MissingDirectory: { `"%s" のディレクトリが存在しません` },
+            InvalidPattern: { `パターン "%s" は無効です: %v` },
+            NoMatches: { `パターン "%s" に一致するものがありません` },
+            InvalidIP: { `IP アドレス "%s" は無効です` },
+            InvalidByteSize: { `バイトサイズ "%s" は無効です` },
+            ByteSizeOutOfRange: { `バイトサイズ "%s" は範囲外です` },
+            InvalidFileMode: { `ファイルモード "%s" は無効です` },
+            InvalidInteger: { `整数 "%s" は無効です` },
+            InvalidNumber: { `数値 "%s" は無効です` },
+            ArgumentErrorPrefix: { `引数 "%s": %w` },
+
+            UnknownOption: { `不明なオプション "%s"` },
+            AmbiguousOption: { `オプション "%s" があいまいです。候補: %s` },
+            OptionNoArgument: { `オプション %s は引数を取りません` },
+            OptionRequiresArgument: { `オプション %s には引数が必要です` },
+            InvalidOptionArgument: { `オプション %[2]s の引数 "%[1]s" は無効です: %[3]v` },
+            OptionNotTerminated: { `オプション %s は "%s" で終わる必要があります` },
+            UnusedOptionArgument: { `引数 "%[1]s" はオプション %[2]s で使用されませんでした` },
+            OptionParamsError: { `オプション %s: %v` },
+
+            UnknownCommand: { `"%[2]s" に不明なコマンド "%[1]s" が指定されました` },
+            MissingCommand: { `"%s" のコマンドがありません。次のいずれかを指定してください: %s` },
+
+            UsageHeading: { `使い方:` },
+            ArgumentsHeading: { `引数:` },
+            CommandsHeading: { `コマンド:` },
+            OptionsHeading: { `オプション:` },
+            ExamplesLabel: { `例:` },
+            EnvironmentLabel: { `環境変数:` },
+            ErrorLabel: { `エラー:` },
+            DeprecatedLabel: { `非推奨:` },
+        },
+    })
+}
@@ -0,0 +1,114 @@
+package options
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/mauricelam/gocmdln/params"
+)
+
+// MarshalArgs returns a canonical argv that parses back to the current values of the options and
+// of the positional parameters in ps, which can be nil. This is the reverse of ParseParams, e.g. to
+// spawn a child process with the same arguments.
+//
+// The options are returned in the order they were defined, using their long names, with their
+// arguments attached like "--file=f" so that arguments starting with "-" are not mistaken for
+// options. Options are omitted if they were not given and their values are the same as when they
+// were defined, as are the options defined by Record and Replay. The positional arguments follow,
+// preceded by "--" if any of them starts with "-", or if a ParamsArgument option without a
+// terminator was written, since it would otherwise capture them. A ParamsArgument option with a
+// Done callback is written once for each occurrence, with the arguments it was parsed from. See
+// params.ParamSet.MarshalArgs.
+func (s *OptionSet) MarshalArgs(ps *params.ParamSet) ([]string, error) {
+    argv := []string {}
+    unterminated := false
+    for _, opt := range s.options {
+        if s.isRecordOption(opt) {
+            continue
+        }
+        args, err := opt.marshalArgs()
+        if err != nil { return nil, fmt.Errorf("Cannot marshal %s: %w", opt.Name(), err) }
+        if len(args) > 0 && opt.Argument == ParamsArgument && opt.Terminator == "" {
+            unterminated = true
+        }
+        argv = append(argv, args...)
+    }
+    positionals, err := ps.MarshalArgs()
+    if err != nil { return nil, err }
+    if len(positionals) > 0 && unterminated {
+        argv = append(argv, "--")
+    } else {
+        for _, arg := range positionals {
+            if strings.HasPrefix(arg, "-") && arg != "-" {
+                argv = append(argv, "--")
+                break
+            }
+        }
+    }
+    return append(argv, positionals...), nil
+}
+
+// marshalArgs returns the arguments that set the current value of the option
+func (o *Option) marshalArgs() ([]string, error) {
+    if o.Argument == ParamsArgument {
+        if !o.IsSet() {
+            return nil, nil
+        }
+        if o.Done != nil {
+            // Done has handled each occurrence, which can't be recovered from the values of Params,
+            // so the occurrences are written as they were parsed
+            args := []string {}
+            for _, occurrence := range o.occurrences {
+                args = append(append(args, o.Name()), occurrence...)
+                if o.Terminator != "" { args = append(args, o.Terminator) }
+            }
+            return args, nil
+        }
+        args, err := o.Params.MarshalArgs()
+        if err != nil { return nil, err }
+        args = append([]string { o.Name() }, args...)
+        if o.Terminator != "" { args = append(args, o.Terminator) }
+        return args, nil
+    }
+    values, err := params.MarshalValue(o.Value)
+    if err != nil { return nil, err }
+    if len(values) != 1 {
+        return nil, fmt.Errorf("Expected 1 argument, but got %d", len(values))
+    }
+    value := values[0]
+    if !o.IsSet() && value == o.defaultArg {
+        return nil, nil
+    }
+    switch o.Argument {
+    case NoArgument:
+        if count, ok := o.Value.(*countValue); ok {
+            args := []string {}
+            for i := 0; i < int(*count); i++ {
+                args = append(args, o.Name())
+            }
+            return args, nil
+        }
+        if value == o.NoArgValue {
+            return []string { o.Name() }, nil
+        }
+        if value == o.NegatedValue && o.Long != "" {
+            return []string { "--no-" + o.Long }, nil
+        }
+        return nil, fmt.Errorf(`Value "%s" cannot be set without an argument`, value)
+    case OptionalArgument:
+        if value == o.NoArgValue {
+            return []string { o.Name() }, nil
+        }
+    }
+    if o.Long != "" {
+        return []string { "--" + o.Long + "=" + value }, nil
+    }
+    if value == "" {
+        // An empty argument cannot be attached to a short option
+        if o.Argument == OptionalArgument {
+            return nil, fmt.Errorf("Empty value cannot be attached to %s", o.Name())
+        }
+        return []string { o.Name(), "" }, nil
+    }
+    return []string { "-" + string(o.Short) + value }, nil
+}
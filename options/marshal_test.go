@@ -0,0 +1,121 @@
+package options
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/mauricelam/gocmdln/params"
+)
+
+// newMarshalOptionSet creates an OptionSet like sed's
+func newMarshalOptionSet() (*OptionSet, *params.ParamSet) {
+    s := &OptionSet{ Mode: Intermixed }
+    s.Bool("quiet", 'n', "")
+    s.String("expression", 'e', "", "")
+    s.OptionalString("in-place", 'i', "", "", "")
+    s.Int("line-length", 'l', 70, "")
+    s.Count("verbose", 'v', "")
+    s.Option(&Option{ Short: 'z', Value: params.NewStringValue("", new(string)), Argument: RequiredArgument })
+    s.Record("record", 0, "")
+    exec := new(params.ParamSet)
+    exec.StringList("command", false, nil)
+    s.ParamsVar(exec, "exec", 0, ";", nil, "")
+    ps := new(params.ParamSet)
+    ps.String("script", true, nil)
+    ps.StringList("files", true, nil)
+    return s, ps
+}
+
+func TestMarshalArgs(t *testing.T) {
+    cases := map[string]struct {
+        argv []string
+        expected []string
+    }{
+        "defaults": { []string {}, []string {} },
+        "flags": {
+            []string { "-vvn", "-i", "s/a/b/", "-l", "0", "f1" },
+            []string { "--quiet", "--in-place", "--line-length=0", "--verbose", "--verbose", "s/a/b/", "f1" },
+        },
+        "dashes": {
+            []string { "-e", "-x", "-z-y", "--", "-s", "-f" },
+            []string { "--expression=-x", "-z-y", "--", "-s", "-f" },
+        },
+        "empty short argument": {
+            []string { "-z", "", "s" },
+            []string { "-z", "", "s" },
+        },
+        "explicit default and negation": {
+            []string { "--in-place=.bak", "--no-quiet", "--record=/dev/null", "--exec", "rm", "-rf", ";", "s" },
+            []string { "--no-quiet", "--in-place=.bak", "--exec", "rm", "-rf", ";", "s" },
+        },
+    }
+    for name, c := range cases {
+
+        // Test setup
+
+        s, ps := newMarshalOptionSet()
+        if err := s.ParseParams(c.argv, ps); err != nil { t.Fatalf("%s: error is not nil: %v", name, err) }
+        values, _ := ps.MarshalArgs()
+
+        // Test execution
+
+        args, err := s.MarshalArgs(ps)
+
+        // Assertions
+
+        if err != nil { t.Errorf("%s: error is not nil: %v", name, err) }
+        if !reflect.DeepEqual(args, c.expected) { t.Errorf("%s: args should be %q, but were %q", name, c.expected, args) }
+        if err := s.ParseParams(args, ps); err != nil { t.Errorf("%s: error parsing %q: %v", name, args, err) }
+        if reparsed, _ := ps.MarshalArgs(); !reflect.DeepEqual(reparsed, values) {
+            t.Errorf("%s: %q should parse back to %q, but was %q", name, args, values, reparsed)
+        }
+    }
+}
+
+func TestMarshalParamsOptionRoundTrip(t *testing.T) {
+    cases := map[string]struct {
+        argv []string
+        expected []string
+    }{
+        "separator": {
+            []string { "-i", "a", "b", "--", "out" },
+            []string { "--input", "a", "b", "--", "out" },
+        },
+        "repeated": {
+            []string { "-i", "in1", "-iin2", "in3", "--", "out" },
+            []string { "--input", "in1", "--input", "in2", "in3", "--", "out" },
+        },
+    }
+    for name, c := range cases {
+
+        // Test setup
+
+        s := new(OptionSet)
+        inputParams := new(params.ParamSet)
+        input := inputParams.StringList("input", false, nil)
+        var inputs [][]string
+        s.ParamsVar(inputParams, "input", 'i', "", func() error {
+            inputs = append(inputs, *input)
+            inputParams.Reset()
+            return nil
+        }, "")
+        ps := new(params.ParamSet)
+        out := ps.String("out", false, nil)
+        if err := s.ParseParams(c.argv, ps); err != nil { t.Fatalf("%s: error is not nil: %v", name, err) }
+        parsed := inputs
+
+        // Test execution
+
+        args, err := s.MarshalArgs(ps)
+        inputs = nil
+        err2 := s.ParseParams(args, ps)
+
+        // Assertions
+
+        if err != nil { t.Errorf("%s: error is not nil: %v", name, err) }
+        if !reflect.DeepEqual(args, c.expected) { t.Errorf("%s: args should be %q, but were %q", name, c.expected, args) }
+        if err2 != nil { t.Errorf("%s: error parsing %q: %v", name, args, err2) }
+        if !reflect.DeepEqual(inputs, parsed) { t.Errorf("%s: %q should parse back to inputs %q, but was %q", name, args, parsed, inputs) }
+        if *out != "out" { t.Errorf(`%s: out should be "out", but was %q`, name, *out) }
+    }
+}
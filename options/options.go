@@ -0,0 +1,534 @@
+// Package options implements a GNU style option parser, to be used with the positional parameters
+// in the params package. It supports short option clusters ("-rn"), long options with "=" or
+// separate values ("--file=f", "--file f"), optional option arguments ("-i[SUFFIX]",
+// "--in-place[=SUFFIX]"), aliases, counting flags ("-vvv") and "--no-" negation.
+package options
+
+import (
+    "fmt"
+    "os"
+    "sort"
+    "strconv"
+    "strings"
+
+    "github.com/mauricelam/gocmdln/i18n"
+    "github.com/mauricelam/gocmdln/params"
+)
+
+// ArgumentKind specifies whether an option takes an argument.
+type ArgumentKind int
+
+const (
+    // NoArgument options, like "--quiet", never take an argument
+    NoArgument ArgumentKind = iota
+    // RequiredArgument options, like "--file f" or "--file=f", always take an argument
+    RequiredArgument
+    // OptionalArgument options, like "--in-place[=SUFFIX]", only take an argument when it is
+    // attached to the option, like "-i.bak" or "--in-place=.bak"
+    OptionalArgument
+    // ParamsArgument options, like "--exec cmd args... ;", take the positional arguments described
+    // by Params
+    ParamsArgument
+)
+
+// Option is the definition of a single option.
+type Option struct {
+    // Long is the long name of the option, used as "--long". It can be empty if Short is set.
+    Long string
+    // Short is the short name of the option, used as "-s". It can be 0 if Long is set.
+    Short rune
+    // Aliases are additional names for the option. Names of a single character are short names.
+    Aliases []string
+    // Usage is the help message of the option.
+    Usage string
+    // Placeholder is the name of the option argument displayed in the usage, e.g. "SCRIPT".
+    Placeholder string
+
+    // Value receives the arguments of the option.
+    Value params.Value
+    // Argument specifies whether the option takes an argument.
+    Argument ArgumentKind
+    // NoArgValue is set onto Value when the option is given without an argument.
+    NoArgValue string
+    // NegatedValue, if not empty, is set onto Value when the option is given as "--no-<long>".
+    NegatedValue string
+
+    // Params allocates the arguments of a ParamsArgument option.
+    Params *params.ParamSet
+    // Terminator, if not empty, is the argument that ends the arguments of a ParamsArgument
+    // option, like ";" for "--exec cmd args... ;".
+    Terminator string
+    // Done, if not nil, is called after each occurrence of a ParamsArgument option is parsed.
+    Done func() error
+
+    seen int
+    set *OptionSet
+    // reset restores Value to its default, see OptionSet.Reset
+    reset params.Resetter
+    // defaultArg is the argument of Value when the option was defined, see OptionSet.MarshalArgs
+    defaultArg string
+    // occurrences are the arguments captured by each occurrence of a ParamsArgument option, which
+    // are marshaled separately when Done handles each occurrence, see Option.marshalArgs
+    occurrences [][]string
+}
+
+// Alias adds additional names for the option. Names of a single character are short names.
+func (o *Option) Alias(names ...string) *Option {
+    o.Aliases = append(o.Aliases, names...)
+    if o.set != nil {
+        for _, name := range names {
+            o.set.register(name, o)
+        }
+    }
+    return o
+}
+
+// IsSet returns whether the option was given in the parsed arguments.
+func (o *Option) IsSet() bool {
+    return o.seen > 0
+}
+
+// Name returns the name of the option as it is used on the command line, e.g. "--quiet" or "-n".
+func (o *Option) Name() string {
+    if o.Long != "" {
+        return "--" + o.Long
+    }
+    return "-" + string(o.Short)
+}
+
+func (o *Option) String() string {
+    return o.Name()
+}
+
+func (o *Option) names() (longs []string, shorts []rune) {
+    if o.Long != "" { longs = append(longs, o.Long) }
+    if o.Short != 0 { shorts = append(shorts, o.Short) }
+    for _, alias := range o.Aliases {
+        if r := []rune(alias); len(r) == 1 {
+            shorts = append(shorts, r[0])
+        } else {
+            longs = append(longs, alias)
+        }
+    }
+    return longs, shorts
+}
+
+// OptionError is the error returned when options cannot be parsed.
+type OptionError struct {
+    err error
+
+    // Suggestions are the options or values the user may have meant, e.g. "quiet" for "--quite"
+    Suggestions []string
+}
+
+// optionError creates an OptionError with the message translated to the current locale
+func optionError(id i18n.MessageID, a ...interface{}) *OptionError {
+    return &OptionError{ err: i18n.Errorf(id, a...) }
+}
+
+func (e *OptionError) Error() string {
+    return e.err.Error()
+}
+
+// Cause (inheritDoc from causer interface)
+func (e *OptionError) Cause() error {
+    return e.err
+}
+
+// ParseMode specifies whether options and positional arguments can be intermixed.
+type ParseMode int
+
+const (
+    // StrictOrder stops parsing options at the first positional argument, as required by POSIX
+    StrictOrder ParseMode = iota
+    // Intermixed allows options to appear anywhere among the positional arguments, until "--".
+    // As in GNU getopt, this falls back to StrictOrder if the POSIXLY_CORRECT environment variable
+    // is set.
+    Intermixed
+)
+
+// OptionSet represents a set of option definitions. The zero value is an empty set ready to use.
+type OptionSet struct {
+    // Mode specifies whether options and positional arguments can be intermixed. The default is
+    // StrictOrder.
+    Mode ParseMode
+
+    options []*Option
+    long map[string]*Option
+    short map[rune]*Option
+    // record and replay are the values of the options defined by Record and Replay
+    record *string
+    replay *string
+    // argv is the arguments of the last Parse, see Record
+    argv []string
+}
+
+// Options returns the options in the order they were added.
+func (s *OptionSet) Options() []*Option {
+    return s.options
+}
+
+// Lookup returns the option with the given long or short name, or nil if there is none.
+func (s *OptionSet) Lookup(name string) *Option {
+    if r := []rune(name); len(r) == 1 {
+        return s.short[r[0]]
+    }
+    return s.long[name]
+}
+
+// Option adds an option to the OptionSet. Option panics if any of the names of the option is
+// already defined, like the flag package does.
+func (s *OptionSet) Option(opt *Option) *Option {
+    if s.long == nil {
+        s.long = make(map[string]*Option)
+        s.short = make(map[rune]*Option)
+    }
+    longs, shorts := opt.names()
+    if len(longs) == 0 && len(shorts) == 0 {
+        panic("option has no name")
+    }
+    for _, name := range longs {
+        s.register(name, opt)
+    }
+    for _, name := range shorts {
+        s.register(string(name), opt)
+    }
+    opt.set = s
+    if opt.Value != nil {
+        // Reuse the reset of a ParamSpec, which restores the value before the first parse
+        opt.reset, _ = params.NewValueParamSpec(opt.Value, opt.Name(), true, nil).(params.Resetter)
+        if args, err := params.MarshalValue(opt.Value); err == nil && len(args) == 1 {
+            opt.defaultArg = args[0]
+        }
+    }
+    s.options = append(s.options, opt)
+    return opt
+}
+
+// Reset resets the values of all the options to their defaults before the first parse, and the
+// Params of the ParamsArgument options, so that the OptionSet can be parsed again without keeping
+// the options of the previous parse. Parse calls Reset before parsing.
+func (s *OptionSet) Reset() {
+    for _, opt := range s.options {
+        opt.seen = 0
+        opt.occurrences = nil
+        if opt.reset != nil { opt.reset.Reset(params.RetainDefaults) }
+        if opt.Argument == ParamsArgument { opt.Params.Reset() }
+    }
+}
+
+func (s *OptionSet) register(name string, opt *Option) {
+    if r := []rune(name); len(r) == 1 {
+        if _, exists := s.short[r[0]]; exists { panic(fmt.Sprintf("option redefined: -%s", name)) }
+        s.short[r[0]] = opt
+        return
+    }
+    if _, exists := s.long[name]; exists { panic(fmt.Sprintf("option redefined: --%s", name)) }
+    s.long[name] = opt
+}
+
+// Var defines an option that takes a required argument, which is set onto the value.
+func (s *OptionSet) Var(value params.Value, long string, short rune, usage string) *Option {
+    return s.Option(&Option{ Long: long, Short: short, Usage: usage, Value: value, Argument: RequiredArgument })
+}
+
+// Bool defines a flag that does not take an argument. It can be negated with "--no-<long>".
+func (s *OptionSet) Bool(long string, short rune, usage string) *bool {
+    var tmp bool
+    s.Option(&Option{
+        Long: long,
+        Short: short,
+        Usage: usage,
+        Value: params.NewBoolValue(false, &tmp),
+        Argument: NoArgument,
+        NoArgValue: "true",
+        NegatedValue: "false",
+    })
+    return &tmp
+}
+
+// String defines an option that takes a required string argument.
+func (s *OptionSet) String(long string, short rune, value string, usage string) *string {
+    var tmp string
+    s.Var(params.NewStringValue(value, &tmp), long, short, usage)
+    return &tmp
+}
+
+// OptionalString defines an option with an optional string argument, like "--in-place[=SUFFIX]".
+// If the option is given without an argument, the value is set to noArgValue.
+func (s *OptionSet) OptionalString(long string, short rune, value string, noArgValue string, usage string) *string {
+    var tmp string
+    s.Option(&Option{
+        Long: long,
+        Short: short,
+        Usage: usage,
+        Value: params.NewStringValue(value, &tmp),
+        Argument: OptionalArgument,
+        NoArgValue: noArgValue,
+    })
+    return &tmp
+}
+
+// Int defines an option that takes a required int argument.
+func (s *OptionSet) Int(long string, short rune, value int, usage string) *int {
+    var tmp int
+    s.Var(params.NewIntValue(value, &tmp), long, short, usage)
+    return &tmp
+}
+
+// countValue is incremented every time it is set to an empty string
+type countValue int
+
+func (c *countValue) Set(s string) error {
+    if s == "" {
+        *c++
+        return nil
+    }
+    v, err := strconv.Atoi(s)
+    *c = countValue(v)
+    return err
+}
+
+func (c *countValue) String() string { return strconv.Itoa(int(*c)) }
+
+// Count defines a flag that counts the number of times it is given, e.g. "-vvv" sets it to 3.
+// "--no-<long>" resets it to 0.
+func (s *OptionSet) Count(long string, short rune, usage string) *int {
+    var tmp int
+    s.Option(&Option{
+        Long: long,
+        Short: short,
+        Usage: usage,
+        Value: (*countValue)(&tmp),
+        Argument: NoArgument,
+        NegatedValue: "0",
+    })
+    return &tmp
+}
+
+// ParamsVar defines an option whose arguments are positional parameters allocated by ps, like
+// "--exec cmd args... ;" in find. If terminator is not empty, the option captures the arguments up
+// to the terminator, which must be present. Otherwise, it captures the arguments that ps can
+// capture before the next option, and the arguments after them are left as positional arguments.
+//
+// done, if not nil, is called after each occurrence of the option is parsed, which allows the
+// option to be repeated, like "-i in1 -i in2" in ffmpeg.
+func (s *OptionSet) ParamsVar(ps *params.ParamSet, long string, short rune, terminator string, done func() error, usage string) *Option {
+    return s.Option(&Option{
+        Long: long,
+        Short: short,
+        Usage: usage,
+        Argument: ParamsArgument,
+        Params: ps,
+        Terminator: terminator,
+        Done: done,
+    })
+}
+
+// lookupLong finds the option by its long name, or an unambiguous prefix of it, as in
+// getopt_long. negated is true if the option was given as "--no-<long>".
+func (s *OptionSet) lookupLong(name string) (opt *Option, negated bool, err error) {
+    if opt, ok := s.long[name]; ok {
+        return opt, false, nil
+    }
+    if name == "" {
+        // "--=x" has no name, which would otherwise be a prefix of every long option
+        return nil, false, optionError(i18n.UnknownOption, "--=")
+    }
+    if strings.HasPrefix(name, "no-") {
+        if opt, ok := s.long[name[3:]]; ok && opt.NegatedValue != "" {
+            return opt, true, nil
+        }
+    }
+    var names, candidates []string
+    for long := range s.long {
+        names = append(names, long)
+        if strings.HasPrefix(long, name) {
+            candidates = append(candidates, long)
+        }
+    }
+    sort.Strings(names)
+    sort.Strings(candidates)
+    switch {
+    case len(candidates) == 0:
+        unknown := params.NewSuggestionError(i18n.Sprintf(i18n.UnknownOption, "--" + name), name, names)
+        unknown.Format = "--%s"
+        return nil, false, &OptionError{ err: unknown, Suggestions: unknown.Suggestions }
+    case len(candidates) > 1 && !sameOption(s.long, candidates):
+        return nil, false, optionError(i18n.AmbiguousOption, "--" + name, "--" + strings.Join(candidates, ", --"))
+    }
+    return s.long[candidates[0]], false, nil
+}
+
+func sameOption(long map[string]*Option, names []string) bool {
+    for _, name := range names[1:] {
+        if long[name] != long[names[0]] { return false }
+    }
+    return true
+}
+
+func (s *OptionSet) set(opt *Option, value string) error {
+    opt.seen++
+    if err := opt.Value.Set(value); err != nil {
+        optErr := optionError(i18n.InvalidOptionArgument, value, opt.Name(), err)
+        if s, ok := err.(*params.SuggestionError); ok {
+            optErr.Suggestions = s.Suggestions
+        }
+        return optErr
+    }
+    return nil
+}
+
+// setParams allocates the leading arguments in argv to the Params of the option, and returns the
+// number of arguments it consumed.
+func (s *OptionSet) setParams(opt *Option, argv []string) (int, error) {
+    opt.seen++
+    n := 0
+    var err error
+    if opt.Terminator != "" {
+        n = -1
+        for i, arg := range argv {
+            if arg == opt.Terminator {
+                n = i
+                break
+            }
+        }
+        if n < 0 {
+            return 0, optionError(i18n.OptionNotTerminated, opt.Name(), opt.Terminator)
+        }
+        // ParsePrefix doesn't reset the values, so that the arguments of repeated occurrences can
+        // accumulate
+        var captured int
+        captured, err = opt.Params.ParsePrefix(argv[:n])
+        if remaining := n - captured; err == nil && remaining > 0 {
+            err = i18n.Nerrorf(i18n.TooManyArguments, remaining, remaining)
+        }
+        opt.occurrences = append(opt.occurrences, append([]string {}, argv[:n]...))
+        // Consume the terminator too
+        n++
+    } else {
+        end := 0
+        for end < len(argv) && !IsOption(argv[end]) {
+            end++
+        }
+        n, err = opt.Params.ParsePrefix(argv[:end])
+        opt.occurrences = append(opt.occurrences, append([]string {}, argv[:n]...))
+    }
+    if err != nil {
+        return 0, optionError(i18n.OptionParamsError, opt.Name(), err)
+    }
+    if opt.Done != nil {
+        if err := opt.Done(); err != nil { return 0, err }
+    }
+    return n, nil
+}
+
+// parseOne parses the option at argv[0], and returns the number of arguments it consumed.
+func (s *OptionSet) parseOne(argv []string) (int, error) {
+    arg := argv[0]
+    if strings.HasPrefix(arg, "--") {
+        name, value, hasValue := arg[2:], "", false
+        if i := strings.Index(name, "="); i >= 0 {
+            name, value, hasValue = name[:i], name[i+1:], true
+        }
+        opt, negated, err := s.lookupLong(name)
+        if err != nil { return 0, err }
+        switch {
+        case opt.Argument == ParamsArgument:
+            if hasValue { return 0, optionError(i18n.OptionNoArgument, "--" + name) }
+            n, err := s.setParams(opt, argv[1:])
+            return 1 + n, err
+        case negated:
+            if hasValue { return 0, optionError(i18n.OptionNoArgument, "--" + name) }
+            return 1, s.set(opt, opt.NegatedValue)
+        case opt.Argument == NoArgument:
+            if hasValue { return 0, optionError(i18n.OptionNoArgument, "--" + name) }
+            return 1, s.set(opt, opt.NoArgValue)
+        case opt.Argument == OptionalArgument && !hasValue:
+            return 1, s.set(opt, opt.NoArgValue)
+        case opt.Argument == RequiredArgument && !hasValue:
+            if len(argv) < 2 { return 0, optionError(i18n.OptionRequiresArgument, "--" + name) }
+            return 2, s.set(opt, argv[1])
+        }
+        return 1, s.set(opt, value)
+    }
+
+    // Short option cluster, e.g. "-rn", "-e script" or "-escript"
+    cluster := []rune(arg[1:])
+    for i, r := range cluster {
+        opt, ok := s.short[r]
+        if !ok { return 0, optionError(i18n.UnknownOption, "-" + string(r)) }
+        rest := string(cluster[i+1:])
+        switch opt.Argument {
+        case NoArgument:
+            if err := s.set(opt, opt.NoArgValue); err != nil { return 0, err }
+            continue
+        case OptionalArgument:
+            if rest == "" { rest = opt.NoArgValue }
+            return 1, s.set(opt, rest)
+        case ParamsArgument:
+            if rest == "" {
+                n, err := s.setParams(opt, argv[1:])
+                return 1 + n, err
+            }
+            // The rest of the cluster is the first argument, like "-iin1". It must be consumed,
+            // since it is part of argv[0]
+            n, err := s.setParams(opt, append([]string { rest }, argv[1:]...))
+            if err == nil && n == 0 {
+                err = optionError(i18n.UnusedOptionArgument, rest, "-" + string(r))
+            }
+            return n, err
+        }
+        if rest != "" {
+            return 1, s.set(opt, rest)
+        }
+        if len(argv) < 2 { return 0, optionError(i18n.OptionRequiresArgument, "-" + string(r)) }
+        return 2, s.set(opt, argv[1])
+    }
+    return 1, nil
+}
+
+// IsOption returns whether the argument looks like an option. "-" on its own is not an option,
+// since it typically refers to stdin.
+func IsOption(arg string) bool {
+    return len(arg) > 1 && arg[0] == '-'
+}
+
+// Parse parses the options in argv, and returns the remaining positional arguments. In StrictOrder
+// mode, parsing stops at the first argument that is not an option, as required by POSIX. In
+// Intermixed mode, options can appear anywhere among the positional arguments. In both modes,
+// parsing stops after "--", which is removed from the returned arguments.
+//
+// The options are reset before parsing, so that the OptionSet can be parsed again, see Reset.
+func (s *OptionSet) Parse(argv []string) ([]string, error) {
+    s.Reset()
+    s.argv = append([]string {}, argv...)
+    intermixed := s.Mode == Intermixed && os.Getenv("POSIXLY_CORRECT") == ""
+    positionals := []string {}
+    for i := 0; i < len(argv); {
+        arg := argv[i]
+        if arg == "--" {
+            return append(positionals, argv[i+1:]...), nil
+        }
+        if !IsOption(arg) {
+            if !intermixed {
+                return append(positionals, argv[i:]...), nil
+            }
+            positionals = append(positionals, arg)
+            i++
+            continue
+        }
+        n, err := s.parseOne(argv[i:])
+        if err != nil { return nil, err }
+        i += n
+    }
+    return positionals, nil
+}
+
+// ParseParams parses the options in argv, and then allocates the remaining positional arguments
+// to the ParamSet, see ParsePositionals.
+func (s *OptionSet) ParseParams(argv []string, ps *params.ParamSet) error {
+    positionals, err := s.Parse(argv)
+    if err != nil { return err }
+    return s.ParsePositionals(positionals, ps)
+}
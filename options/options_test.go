@@ -0,0 +1,248 @@
+package options
+
+import (
+    "os"
+    "reflect"
+    "testing"
+
+    "github.com/mauricelam/gocmdln/i18n"
+    "github.com/mauricelam/gocmdln/params"
+)
+
+func init() {
+    // The tests compare the English messages, regardless of the locale of the environment
+    i18n.SetLocale("en")
+}
+
+func TestShortClusters(t *testing.T) {
+
+    // Test setup
+
+    s := new(OptionSet)
+    regexp := s.Bool("regexp-extended", 'r', "")
+    quiet := s.Bool("quiet", 'n', "")
+    script := s.String("expression", 'e', "", "")
+
+    // Test execution
+
+    args, err := s.Parse([]string { "-rn", "-es/a/b/", "file" })
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if !*regexp || !*quiet { t.Errorf("regexp and quiet should be set") }
+    if *script != "s/a/b/" { t.Errorf(`script should be "s/a/b/", but was %s`, *script) }
+    if !reflect.DeepEqual(args, []string { "file" }) { t.Errorf("Unexpected args: %v", args) }
+}
+
+func TestLongOptions(t *testing.T) {
+
+    // Test setup
+
+    s := new(OptionSet)
+    file := s.String("file", 'f', "", "")
+    lineLength := s.Int("line-length", 'l', 70, "")
+    quiet := s.Bool("quiet", 'n', "")
+    s.Lookup("quiet").Alias("silent")
+
+    // Test execution
+
+    args, err := s.Parse([]string { "--file", "a.sed", "--line-len=80", "--silent", "--", "-x" })
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if *file != "a.sed" { t.Errorf(`file should be "a.sed", but was %s`, *file) }
+    if *lineLength != 80 { t.Errorf("lineLength should be 80, but was %d", *lineLength) }
+    if !*quiet { t.Errorf("quiet should be set") }
+    if !reflect.DeepEqual(args, []string { "-x" }) { t.Errorf("Unexpected args: %v", args) }
+}
+
+func TestOptionalArguments(t *testing.T) {
+    cases := []struct {
+        argv []string
+        expected string
+        args []string
+    }{
+        { []string { "-i" }, "*", []string {} },
+        { []string { "-i.bak", "file" }, ".bak", []string { "file" } },
+        { []string { "--in-place", "file" }, "*", []string { "file" } },
+        { []string { "--in-place=.orig" }, ".orig", []string {} },
+        { []string { "file" }, "", []string { "file" } },
+    }
+    for _, c := range cases {
+        s := new(OptionSet)
+        inPlace := s.OptionalString("in-place", 'i', "", "*", "")
+        args, err := s.Parse(c.argv)
+
+        if err != nil { t.Errorf("Error for %v: %v", c.argv, err) }
+        if *inPlace != c.expected { t.Errorf("%v: inPlace should be %q, but was %q", c.argv, c.expected, *inPlace) }
+        if !reflect.DeepEqual(args, c.args) { t.Errorf("%v: unexpected args %v", c.argv, args) }
+        if s.Lookup("in-place").IsSet() != (c.expected != "") { t.Errorf("%v: unexpected IsSet", c.argv) }
+    }
+}
+
+func TestCountAndNegation(t *testing.T) {
+    // Setup
+    s := new(OptionSet)
+    verbose := s.Count("verbose", 'v', "")
+    color := s.Bool("color", 0, "")
+
+    // Execution
+    _, err := s.Parse([]string { "-vvv", "--color", "--verbose", "--no-color" })
+
+    // Assertions
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    if *verbose != 4 { t.Errorf("verbose should be 4, but was %d", *verbose) }
+    if *color { t.Errorf("color should be negated") }
+}
+
+func TestStopsAtFirstPositional(t *testing.T) {
+    // Setup
+    s := new(OptionSet)
+    quiet := s.Bool("quiet", 'n', "")
+
+    // Execution
+    args, err := s.Parse([]string { "-", "-n" })
+
+    // Assertions
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    if *quiet { t.Errorf("quiet should not be set") }
+    if !reflect.DeepEqual(args, []string { "-", "-n" }) { t.Errorf("Unexpected args: %v", args) }
+}
+
+func TestOptionErrors(t *testing.T) {
+    s := new(OptionSet)
+    s.Bool("quiet", 'n', "")
+    s.Bool("quick", 0, "")
+    s.Int("line-length", 'l', 0, "")
+
+    cases := map[string][]string {
+        `Unknown option "-x"`: { "-nx" },
+        `Unknown option "--loud"`: { "--loud" },
+        `Ambiguous option "--qui" could be --quick, --quiet`: { "--qui" },
+        `Unknown option "--="`: { "--=x" },
+        `Option --quiet does not take an argument`: { "--quiet=yes" },
+        `Option -l requires an argument`: { "-l" },
+        `Invalid argument "abc" for option --line-length: strconv.ParseInt: parsing "abc": invalid syntax`: { "-labc" },
+    }
+    for expected, argv := range cases {
+        _, err := s.Parse(argv)
+        if err == nil || err.Error() != expected {
+            t.Errorf("%v: error should be %q, but was: %v", argv, expected, err)
+        }
+    }
+}
+
+func TestEmptyLongOptionName(t *testing.T) {
+
+    // Test setup
+
+    s := new(OptionSet)
+    quiet := s.String("quiet", 0, "", "")
+
+    // Test execution
+
+    _, err := s.Parse([]string { "--=x" })
+
+    // Assertions
+
+    if err == nil || err.Error() != `Unknown option "--="` { t.Errorf("Error should be an unknown option, but was: %v", err) }
+    if *quiet != "" { t.Errorf(`quiet should not be set, but was %q`, *quiet) }
+}
+
+func TestRedefinitionPanics(t *testing.T) {
+    defer func() {
+        if recover() == nil { t.Errorf("Redefinition should panic") }
+    }()
+    s := new(OptionSet)
+    s.Bool("quiet", 'n', "")
+    s.Bool("silent", 'n', "")
+}
+
+func TestIntermixedParsing(t *testing.T) {
+
+    // Test setup
+
+    s := &OptionSet{ Mode: Intermixed }
+    quiet := s.Bool("quiet", 'n', "")
+    script := s.String("expression", 'e', "", "")
+    ps := new(params.ParamSet)
+    files := ps.StringList("files", false, nil)
+
+    // Test execution
+
+    err := s.ParseParams([]string { "a", "-n", "b", "--expression", "p", "--", "-c", "-n" }, ps)
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if !*quiet { t.Errorf("quiet should be set") }
+    if *script != "p" { t.Errorf(`script should be "p", but was %s`, *script) }
+    if !reflect.DeepEqual(*files, []string { "a", "b", "-c", "-n" }) {
+        t.Errorf("Unexpected files: %v", *files)
+    }
+}
+
+func TestPosixlyCorrect(t *testing.T) {
+    // Setup
+    os.Setenv("POSIXLY_CORRECT", "1")
+    defer os.Unsetenv("POSIXLY_CORRECT")
+    s := &OptionSet{ Mode: Intermixed }
+    quiet := s.Bool("quiet", 'n', "")
+
+    // Execution
+    args, err := s.Parse([]string { "a", "-n" })
+
+    // Assertions
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    if *quiet { t.Errorf("quiet should not be set") }
+    if !reflect.DeepEqual(args, []string { "a", "-n" }) { t.Errorf("Unexpected args: %v", args) }
+}
+
+func TestUnknownOptionSuggestions(t *testing.T) {
+    // Setup
+    s := new(OptionSet)
+    s.Bool("quiet", 'q', "")
+    s.Bool("verbose", 'v', "")
+
+    // Execution
+    _, err := s.Parse([]string { "--quite" })
+
+    // Assertions
+    if err == nil || err.Error() != `Unknown option "--quite". Did you mean --quiet?` {
+        t.Errorf("Unexpected error: %v", err)
+    }
+    if optErr, ok := err.(*OptionError); !ok || len(optErr.Suggestions) != 1 || optErr.Suggestions[0] != "quiet" {
+        t.Errorf("Unexpected suggestions: %#v", err)
+    }
+}
+
+func TestReparseResetsValues(t *testing.T) {
+
+    // Test setup
+
+    s := new(OptionSet)
+    verbose := s.Count("verbose", 'v', "")
+    output := s.String("output", 'o', "-", "")
+    context := s.Int("context", 'C', 3, "")
+
+    // Test execution
+
+    _, err1 := s.Parse([]string { "-vv", "-o", "out.txt", "-C", "5" })
+    _, err2 := s.Parse([]string { "-v" })
+
+    // Assertions
+
+    if err1 != nil || err2 != nil { t.Errorf("Errors should be nil, but were %v, %v", err1, err2) }
+    if *verbose != 1 { t.Errorf("verbose should be 1, but was %d", *verbose) }
+    if *output != "-" { t.Errorf(`output should be reset to "-", but was %q`, *output) }
+    if *context != 3 { t.Errorf("context should be reset to 3, but was %d", *context) }
+}
+
+func TestIsOption(t *testing.T) {
+    cases := map[string]bool { "-n": true, "--quiet": true, "--": true, "-": false, "file": false, "": false }
+    for arg, expected := range cases {
+        if IsOption(arg) != expected { t.Errorf("IsOption(%q) should be %v", arg, expected) }
+    }
+}
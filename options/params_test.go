@@ -0,0 +1,136 @@
+package options
+
+import (
+    "reflect"
+    "regexp"
+    "testing"
+
+    "github.com/mauricelam/gocmdln/params"
+)
+
+func TestParamsOptionWithTerminator(t *testing.T) {
+
+    // Test setup
+
+    s := &OptionSet{ Mode: Intermixed }
+    execParams := new(params.ParamSet)
+    command := execParams.String("command", false, nil)
+    args := execParams.StringList("args", true, nil)
+    s.ParamsVar(execParams, "exec", 0, ";", nil, "execute command")
+    name := s.String("name", 0, "", "")
+
+    // Test execution
+
+    positionals, err := s.Parse([]string { ".", "--exec", "rm", "-f", "{}", ";", "--name", "*.go" })
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if *command != "rm" { t.Errorf(`command should be "rm", but was %s`, *command) }
+    if !reflect.DeepEqual(*args, []string { "-f", "{}" }) { t.Errorf("Unexpected args: %v", *args) }
+    if *name != "*.go" { t.Errorf(`name should be "*.go", but was %s`, *name) }
+    if !reflect.DeepEqual(positionals, []string { "." }) { t.Errorf("Unexpected positionals: %v", positionals) }
+}
+
+func TestRepeatedParamsOption(t *testing.T) {
+
+    // Test setup
+
+    s := new(OptionSet)
+    inputParams := new(params.ParamSet)
+    input := inputParams.String("input", false, nil)
+    var inputs []string
+    s.ParamsVar(inputParams, "input", 'i', "", func() error {
+        inputs = append(inputs, *input)
+        return nil
+    }, "")
+
+    // Test execution
+
+    positionals, err := s.Parse([]string { "-i", "in1", "-iin2", "out" })
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if !reflect.DeepEqual(inputs, []string { "in1", "in2" }) { t.Errorf("Unexpected inputs: %v", inputs) }
+    if !reflect.DeepEqual(positionals, []string { "out" }) { t.Errorf("Unexpected positionals: %v", positionals) }
+}
+
+func TestParamsOptionReparse(t *testing.T) {
+
+    // Test setup
+
+    s := new(OptionSet)
+    execParams := new(params.ParamSet)
+    args := execParams.StringList("args", false, nil)
+    s.ParamsVar(execParams, "exec", 0, ";", nil, "")
+
+    // Test execution
+
+    _, err1 := s.Parse([]string { "--exec", "a", ";", "--exec", "b", ";" })
+    accumulated := append([]string {}, *args...)
+    _, err2 := s.Parse([]string { "--exec", "c", ";" })
+
+    // Assertions
+
+    if err1 != nil || err2 != nil { t.Errorf("Errors should be nil, but were %v, %v", err1, err2) }
+    if !reflect.DeepEqual(accumulated, []string { "a", "b" }) { t.Errorf("Repeated occurrences should accumulate: %v", accumulated) }
+    if !reflect.DeepEqual(*args, []string { "c" }) { t.Errorf("Parsing again should reset the args: %v", *args) }
+}
+
+func TestParamsOptionErrors(t *testing.T) {
+    s := new(OptionSet)
+    execParams := new(params.ParamSet)
+    execParams.String("command", false, nil)
+    s.ParamsVar(execParams, "exec", 0, ";", nil, "")
+    s.ParamsVar(execParams, "run", 0, "", nil, "")
+
+    cases := map[string][]string {
+        `Option --exec must be terminated by ";"`: { "--exec", "rm" },
+        `Option --exec: Missing required argument "command"`: { "--exec", ";" },
+        `Option --run: Missing required argument "command"`: { "--run", "--exec" },
+    }
+    for expected, argv := range cases {
+        _, err := s.Parse(argv)
+        if err == nil || err.Error() != expected {
+            t.Errorf("%v: error should be %q, but was: %v", argv, expected, err)
+        }
+    }
+}
+
+func TestParamsOptionAttachedArgument(t *testing.T) {
+
+    // Test setup
+
+    s := new(OptionSet)
+    inputParams := new(params.ParamSet)
+    input := inputParams.StringPattern("input", regexp.MustCompile(`^in`), true, nil)
+    s.ParamsVar(inputParams, "input", 'i', "", nil, "")
+
+    // Test execution
+
+    positionals, err1 := s.Parse([]string { "-iin1", "x" })
+    captured := append([]string {}, *input...)
+    _, err2 := s.Parse([]string { "-iFOO", "x" })
+
+    // Assertions
+
+    if err1 != nil { t.Errorf("Error is not nil: %v", err1) }
+    if !reflect.DeepEqual(captured, []string { "in1" }) { t.Errorf("Unexpected input: %v", captured) }
+    if !reflect.DeepEqual(positionals, []string { "x" }) { t.Errorf("Unexpected positionals: %v", positionals) }
+    expected := `Argument "FOO" was not used by option -i`
+    if err2 == nil || err2.Error() != expected { t.Errorf("Error should be %q, but was: %v", expected, err2) }
+    if _, ok := err2.(*OptionError); !ok { t.Errorf("Error should be an OptionError, but was %T", err2) }
+}
+
+func TestParamsOptionSynopsis(t *testing.T) {
+    s := new(OptionSet)
+    execParams := new(params.ParamSet)
+    execParams.String("command", false, nil)
+    execParams.StringList("args", true, nil)
+    opt := s.ParamsVar(execParams, "exec", 0, ";", nil, "")
+
+    if synopsis := opt.Synopsis(); synopsis != "--exec <command> [<args>...] ;" {
+        t.Errorf("Unexpected synopsis: %s", synopsis)
+    }
+}
@@ -0,0 +1,147 @@
+package options
+
+import (
+    "fmt"
+    "reflect"
+
+    "github.com/mauricelam/gocmdln/i18n"
+    "github.com/mauricelam/gocmdln/params"
+)
+
+// Record defines an option, typically "--record FILE", that saves a params.Recording to the file
+// when the positional parameters are parsed with ParsePositionals or ParseParams. The recording
+// has the whole argv given to Parse, and the values of the options as well as of the positional
+// parameters.
+func (s *OptionSet) Record(long string, short rune, usage string) *string {
+    s.record = s.String(long, short, "", usage)
+    return s.record
+}
+
+// Replay defines an option, typically "--replay FILE", that replays the params.Recording in the
+// file instead of parsing the positional arguments, when they are parsed with ParsePositionals or
+// ParseParams. The options are set to the recorded values too, replacing the other options that
+// were given. No positional arguments may be given together with the option.
+func (s *OptionSet) Replay(long string, short rune, usage string) *string {
+    s.replay = s.String(long, short, "", usage)
+    return s.replay
+}
+
+// ParsePositionals parses the positional arguments returned by Parse into the ParamSet, or replays
+// or records them if the options defined by Replay or Record were given.
+func (s *OptionSet) ParsePositionals(positionals []string, ps *params.ParamSet) error {
+    if s.replay != nil && *s.replay != "" {
+        if len(positionals) > 0 {
+            err := params.NewArgumentError(params.ErrTooManyArguments, "", i18n.Nerrorf(i18n.TooManyArguments, len(positionals), len(positionals)))
+            err.Args = positionals
+            return err
+        }
+        recording, err := params.ReadRecordingFile(*s.replay)
+        if err != nil { return err }
+        if recording.Options != nil {
+            // The recording was made by Record, not by params.ParamSet.ParseRecord
+            if err := s.replayOptions(recording.Options); err != nil { return err }
+        }
+        return ps.Replay(recording)
+    }
+    if s.record != nil && *s.record != "" {
+        recording, err := ps.ParseRecord(positionals)
+        if err != nil { return err }
+        recording.Argv = s.argv
+        recording.Options, err = s.recordOptions()
+        if err != nil { return err }
+        return recording.WriteFile(*s.record)
+    }
+    return ps.Parse(positionals)
+}
+
+// recordOptions returns the values of the options, except those defined by Record and Replay. The
+// Args of options given on the command line are their canonical arguments, see MarshalArgs, and
+// the Args of the other options are the arguments of their Values, see params.MarshalValue.
+func (s *OptionSet) recordOptions() ([]params.RecordedParam, error) {
+    recorded := []params.RecordedParam {}
+    for _, opt := range s.options {
+        if s.isRecordOption(opt) {
+            continue
+        }
+        param := params.RecordedParam{ Name: opt.Name(), Source: params.SourceDefault }
+        if opt.Value != nil {
+            param.Value = fmt.Sprint(opt.Value)
+        }
+        var err error
+        switch {
+        case opt.IsSet():
+            param.Source = params.SourceArgs
+            param.Args, err = opt.marshalArgs()
+        case opt.Value != nil:
+            param.Args, err = params.MarshalValue(opt.Value)
+            if err == nil && !reflect.DeepEqual(param.Args, []string { opt.defaultArg }) {
+                param.Source = params.SourceProgram
+            }
+        }
+        if err != nil { return nil, fmt.Errorf("Cannot record %s: %w", opt.Name(), err) }
+        recorded = append(recorded, param)
+    }
+    return recorded, nil
+}
+
+// replayOptions sets the options to the values recorded by recordOptions. The options given on the
+// command line are parsed again from their recorded arguments, and the other options are cleared
+// and set to the recorded arguments if their values are different.
+func (s *OptionSet) replayOptions(recorded []params.RecordedParam) error {
+    var options []*Option
+    var argv []string
+    for _, opt := range s.options {
+        if s.isRecordOption(opt) {
+            continue
+        }
+        i := len(options)
+        if i >= len(recorded) || recorded[i].Name != opt.Name() {
+            return fmt.Errorf("Recorded options do not match option %s", opt.Name())
+        }
+        switch recorded[i].Source {
+        case params.SourceArgs:
+            argv = append(argv, recorded[i].Args...)
+        case params.SourceProgram, params.SourceDefault:
+        default:
+            return fmt.Errorf("Unknown source %q of recorded option %s", recorded[i].Source, opt.Name())
+        }
+        options = append(options, opt)
+    }
+    if len(recorded) != len(options) {
+        return fmt.Errorf("Recording has %d options, but %d were expected", len(recorded), len(options))
+    }
+    positionals, err := s.Parse(argv)
+    if err != nil { return err }
+    if len(positionals) > 0 {
+        return fmt.Errorf("Recorded options %q have positional arguments", argv)
+    }
+    for i, opt := range options {
+        if recorded[i].Source == params.SourceArgs || opt.Value == nil || recorded[i].Args == nil {
+            continue
+        }
+        if err := restoreOption(opt, recorded[i].Args); err != nil {
+            return fmt.Errorf("Cannot restore the recorded value %q of %s: %w", recorded[i].Value, opt.Name(), err)
+        }
+    }
+    return nil
+}
+
+// restoreOption clears the value of the option and sets it to args, if its current value has
+// different arguments
+func restoreOption(opt *Option, args []string) error {
+    current, err := params.MarshalValue(opt.Value)
+    if err == nil && reflect.DeepEqual(current, args) {
+        return nil
+    }
+    if opt.reset != nil { opt.reset.Reset(params.ClearValues) }
+    for _, arg := range args {
+        if err := opt.Value.Set(arg); err != nil { return err }
+    }
+    return nil
+}
+
+// isRecordOption returns whether the option was defined by Record or Replay
+func (s *OptionSet) isRecordOption(opt *Option) bool {
+    value, ok := opt.Value.(*params.StringValue)
+    return ok && (s.record == (*string)(value) || s.replay == (*string)(value))
+}
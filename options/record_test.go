@@ -0,0 +1,54 @@
+package options
+
+import (
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "reflect"
+    "testing"
+
+    "github.com/mauricelam/gocmdln/params"
+)
+
+func TestRecordAndReplayOptions(t *testing.T) {
+
+    // Test setup
+
+    dir, err := ioutil.TempDir("", "record")
+    if err != nil { t.Fatal(err) }
+    defer os.RemoveAll(dir)
+    file := filepath.Join(dir, "run.json")
+    s := new(OptionSet)
+    s.Record("record", 0, "Record the arguments to FILE")
+    s.Replay("replay", 0, "Replay the arguments from FILE")
+    verbose := s.Count("verbose", 'v', "")
+    name := s.String("name", 'n', "", "")
+    dryRun := s.Bool("dry-run", 0, "")
+    ps := new(params.ParamSet)
+    inputs := ps.StringList("inputs", false, nil)
+    // The program sets the default of --name before parsing, e.g. from the environment
+    *name = "env"
+
+    // Test execution
+
+    err1 := s.ParseParams([]string { "-vv", "--record", file, "--dry-run", "a", "b" }, ps)
+    recording, _ := params.ReadRecordingFile(file)
+    *name = "other"
+    err2 := s.ParseParams([]string { "-n", "x", "c" }, ps)
+    err4 := s.ParseParams([]string { "--replay", file, "d" }, ps)
+    err3 := s.ParseParams([]string { "--replay", file }, ps)
+
+    // Assertions
+
+    if err1 != nil || err2 != nil || err3 != nil { t.Errorf("Errors should be nil, but were %v, %v, %v", err1, err2, err3) }
+    if !reflect.DeepEqual(*inputs, []string { "a", "b" }) { t.Errorf(`inputs should be replayed as ["a" "b"], but were %q`, *inputs) }
+    if *verbose != 2 || *name != "env" || !*dryRun { t.Errorf("Options should be replayed, but were %d %q %v", *verbose, *name, *dryRun) }
+    if params.ExitCode(err4) != 2 { t.Errorf("Positional arguments with --replay should be an error, but was %v", err4) }
+    if !reflect.DeepEqual(recording.Argv, []string { "-vv", "--record", file, "--dry-run", "a", "b" }) { t.Errorf("Unexpected argv: %q", recording.Argv) }
+    expected := []params.RecordedParam {
+        { Name: "--verbose", Source: params.SourceArgs, Args: []string { "--verbose", "--verbose" }, Value: "2" },
+        { Name: "--name", Source: params.SourceProgram, Args: []string { "env" }, Value: "env" },
+        { Name: "--dry-run", Source: params.SourceArgs, Args: []string { "--dry-run" }, Value: "true" },
+    }
+    if !reflect.DeepEqual(recording.Options, expected) { t.Errorf("Unexpected options: %+v", recording.Options) }
+}
@@ -0,0 +1,114 @@
+package options
+
+import (
+    "fmt"
+    "io"
+    "strings"
+
+    "github.com/mauricelam/gocmdln/i18n"
+    "github.com/mauricelam/gocmdln/params"
+)
+
+// placeholder returns the name of the option argument displayed in the usage
+func (o *Option) placeholder() string {
+    if o.Placeholder != "" {
+        return o.Placeholder
+    }
+    if o.Long != "" {
+        return strings.ToUpper(strings.Replace(o.Long, "-", "_", -1))
+    }
+    return "VALUE"
+}
+
+// Synopsis returns the names of the option as displayed in the usage, e.g. "-n, --quiet, --silent"
+// or "-i, --in-place[=SUFFIX]".
+func (o *Option) Synopsis() string {
+    longs, shorts := o.names()
+    var names []string
+    for _, short := range shorts {
+        names = append(names, "-" + string(short))
+    }
+    for _, long := range longs {
+        names = append(names, "--" + long)
+    }
+    s := strings.Join(names, ", ")
+    longForm := len(longs) > 0
+    switch o.Argument {
+    case RequiredArgument:
+        if longForm {
+            s += "=" + o.placeholder()
+        } else {
+            s += " " + o.placeholder()
+        }
+    case OptionalArgument:
+        if longForm {
+            s += "[=" + o.placeholder() + "]"
+        } else {
+            s += "[" + o.placeholder() + "]"
+        }
+    case ParamsArgument:
+        if synopsis := o.Params.Synopsis(); synopsis != "" {
+            s += " " + synopsis
+        }
+        if o.Terminator != "" {
+            s += " " + o.Terminator
+        }
+    }
+    return s
+}
+
+// PrintDefaults prints the usage of all the options in the OptionSet to w, in the format
+//
+//   -n, --quiet, --silent
+//         suppress automatic printing of pattern space
+func (s *OptionSet) PrintDefaults(w io.Writer) {
+    for _, opt := range s.options {
+        fmt.Fprintf(w, "  %s\n", opt.Synopsis())
+        if opt.Usage != "" {
+            fmt.Fprintf(w, "        %s\n", strings.Replace(opt.Usage, "\n", "\n        ", -1))
+        }
+    }
+}
+
+// PrintUsage prints the usage of a program with the given options and positional parameters to w,
+// for example
+//
+//   Usage: sed [OPTION]... <command> [<inputFiles>...]
+//
+//   Options:
+//     -n, --quiet, --silent
+//           suppress automatic printing of pattern space
+//
+// If any of the positional parameters has a description in its params.Help, they are listed in an
+// "Arguments:" section before the options.
+func PrintUsage(w io.Writer, program string, s *OptionSet, ps *params.ParamSet) {
+    synopsis := []string { program }
+    if s != nil && len(s.options) > 0 {
+        synopsis = append(synopsis, "[OPTION]...")
+    }
+    if positional := ps.Synopsis(); positional != "" {
+        synopsis = append(synopsis, positional)
+    }
+    fmt.Fprintf(w, "%s %s\n", i18n.Sprintf(i18n.UsageHeading), strings.Join(synopsis, " "))
+    if hasParamsHelp(ps) {
+        fmt.Fprintf(w, "\n%s\n", i18n.Sprintf(i18n.ArgumentsHeading))
+        ps.PrintDefaults(w)
+    }
+    if s != nil && len(s.options) > 0 {
+        fmt.Fprintf(w, "\n%s\n", i18n.Sprintf(i18n.OptionsHeading))
+        s.PrintDefaults(w)
+    }
+}
+
+// hasParamsHelp returns whether any of the params has a description to print
+func hasParamsHelp(ps *params.ParamSet) bool {
+    if ps == nil {
+        return false
+    }
+    for _, paramSpec := range *ps {
+        if help := params.HelpOf(paramSpec); help.Short != "" || help.Long != "" {
+            return true
+        }
+    }
+    return false
+}
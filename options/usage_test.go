@@ -0,0 +1,72 @@
+package options
+
+import (
+    "bytes"
+    "testing"
+
+    "github.com/mauricelam/gocmdln/params"
+)
+
+func TestPrintUsage(t *testing.T) {
+    // Setup
+    s := new(OptionSet)
+    s.Option(&Option{ Long: "quiet", Short: 'n', Usage: "suppress automatic printing", Argument: NoArgument }).Alias("silent")
+    s.OptionalString("in-place", 'i', "", "", "edit files in place")
+    s.Lookup("in-place").Placeholder = "SUFFIX"
+    s.String("expression", 'e', "", "add the script")
+    s.Lookup("expression").Placeholder = "script"
+    s.Int("", 'l', 0, "line length")
+    ps := new(params.ParamSet)
+    ps.String("command", false, nil)
+    ps.StringList("inputFiles", true, nil)
+
+    // Execution
+    var buf bytes.Buffer
+    PrintUsage(&buf, "sed", s, ps)
+
+    // Assertions
+    expected := `Usage: sed [OPTION]... <command> [<inputFiles>...]
+
+Options:
+  -n, --quiet, --silent
+        suppress automatic printing
+  -i, --in-place[=SUFFIX]
+        edit files in place
+  -e, --expression=script
+        add the script
+  -l VALUE
+        line length
+`
+    if buf.String() != expected {
+        t.Errorf("Unexpected usage:\n%s", buf.String())
+    }
+}
+
+func TestPrintUsageWithArguments(t *testing.T) {
+    // Setup
+    s := new(OptionSet)
+    s.Bool("quiet", 'n', "suppress automatic printing")
+    ps := new(params.ParamSet)
+    ps.String("command", false, params.Help{ Short: "the sed script" })
+    ps.StringList("inputFiles", true, params.Help{ Placeholder: "FILE" })
+
+    // Execution
+    var buf bytes.Buffer
+    PrintUsage(&buf, "sed", s, ps)
+
+    // Assertions
+    expected := `Usage: sed [OPTION]... <command> [<FILE>...]
+
+Arguments:
+  <command>
+        the sed script
+  <FILE>...
+
+Options:
+  -n, --quiet
+        suppress automatic printing
+`
+    if buf.String() != expected {
+        t.Errorf("Unexpected usage:\n%s", buf.String())
+    }
+}
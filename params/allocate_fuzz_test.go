@@ -0,0 +1,32 @@
+//go:build go1.18
+// +build go1.18
+
+package params
+
+import (
+    "testing"
+)
+
+func FuzzAllocate(f *testing.F) {
+    f.Add([]byte { 1, 1, 0, 3 }, 2)
+    f.Add([]byte { 0, 0, 1, 2, 1, 1 }, 1)
+    f.Add([]byte { 2, 3, 0, 0, 1, 1 }, 5)
+    f.Fuzz(func(t *testing.T, config []byte, argc int) {
+        // Each pair of bytes is the min length and max length of a spec, where max lengths larger
+        // than 4 are unbounded
+        if len(config) > 10 || argc < 0 || argc > 12 {
+            t.Skip()
+        }
+        var specs []lengthSpec
+        for i := 0; i + 1 < len(config); i += 2 {
+            min, max := int(config[i] % 4), int(config[i + 1] % 8)
+            if max > 4 {
+                max = 4 - max
+            } else if max < min {
+                max = min
+            }
+            specs = append(specs, lengthSpec{ min, max })
+        }
+        checkAllocation(t, specs, argc)
+    })
+}
@@ -0,0 +1,149 @@
+package params
+
+import (
+    "fmt"
+    "reflect"
+    "testing"
+)
+
+// lengthSpec is the min and max length of a ParamSpec in an allocation test
+type lengthSpec struct {
+    min int
+    max int
+}
+
+func newLengthParamSet(specs []lengthSpec) *ParamSet {
+    p := new(ParamSet)
+    for i, spec := range specs {
+        p.VarListCustom(new(StringValueList), fmt.Sprintf("p%d", i), spec.min, spec.max, nil)
+    }
+    return p
+}
+
+// bruteForceAllocate is the reference allocator. It enumerates all the allocations of argc
+// arguments that satisfy the min and max lengths, and returns the one where the earlier specs
+// capture the most arguments. If there are not enough arguments, it returns the index of the first
+// required spec that cannot be filled from the left, or if there are too many, the number of
+// remaining arguments.
+func bruteForceAllocate(specs []lengthSpec, argc int) (lengths []int, missing int, remaining int) {
+    minTotal := 0
+    for i, spec := range specs {
+        minTotal += spec.min
+        if minTotal > argc {
+            return nil, i, 0
+        }
+    }
+    var best []int
+    current := make([]int, len(specs))
+    var search func(i int, left int)
+    search = func(i int, left int) {
+        if i == len(specs) {
+            if left == 0 && (best == nil || lexicographicallyGreater(current, best)) {
+                best = append([]int {}, current...)
+            }
+            return
+        }
+        for l := specs[i].min; l <= left && (specs[i].max < 0 || l <= specs[i].max); l++ {
+            current[i] = l
+            search(i + 1, left - l)
+        }
+    }
+    search(0, argc)
+    if best == nil {
+        maxTotal := 0
+        for _, spec := range specs {
+            maxTotal += spec.max
+        }
+        return nil, -1, argc - maxTotal
+    }
+    return best, -1, 0
+}
+
+func lexicographicallyGreater(a, b []int) bool {
+    for i := range a {
+        if a[i] != b[i] {
+            return a[i] > b[i]
+        }
+    }
+    return false
+}
+
+// checkAllocation compares the allocation of the ParamSet with the reference allocator
+func checkAllocation(t *testing.T, specs []lengthSpec, argc int) {
+    argv := make([]string, argc)
+    for i := range argv {
+        argv[i] = fmt.Sprintf("arg%d", i)
+    }
+    lengths, err := newLengthParamSet(specs).allocate(argv, false, nil)
+    expected, missing, remaining := bruteForceAllocate(specs, argc)
+    switch {
+    case missing >= 0:
+        if message := fmt.Sprintf(`Missing required argument "p%d"`, missing); err == nil || err.Error() != message {
+            t.Errorf("%v with %d arguments: expected error %s, but was %v %v", specs, argc, message, lengths, err)
+        }
+    case expected == nil:
+        if message := fmt.Sprintf(`Too many arguments. %d remaining`, remaining); err == nil || err.Error() != message {
+            t.Errorf("%v with %d arguments: expected error %s, but was %v %v", specs, argc, message, lengths, err)
+        }
+    case err != nil:
+        t.Errorf("%v with %d arguments: expected %v, but was %v", specs, argc, expected, err)
+    case !reflect.DeepEqual(lengths, expected):
+        t.Errorf("%v with %d arguments: expected %v, but was %v", specs, argc, expected, lengths)
+    }
+}
+
+func TestAllocateMatchesBruteForce(t *testing.T) {
+    // All the combinations of up to 3 specs with lengths up to 2, and up to 6 arguments
+    var lengthSpecs []lengthSpec
+    for min := 0; min <= 2; min++ {
+        for max := -1; max <= 2; max++ {
+            if max < 0 || max >= min {
+                lengthSpecs = append(lengthSpecs, lengthSpec{ min, max })
+            }
+        }
+    }
+    var specs []lengthSpec
+    var each func(depth int)
+    each = func(depth int) {
+        for argc := 0; argc <= 6; argc++ {
+            checkAllocation(t, specs, argc)
+        }
+        if depth == 3 {
+            return
+        }
+        for _, spec := range lengthSpecs {
+            specs = append(specs, spec)
+            each(depth + 1)
+            specs = specs[:len(specs) - 1]
+        }
+    }
+    each(0)
+}
+
+func TestNegativeMaxLengthIsUnbounded(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    list := p.StringListCustom("list", 1, -2, nil)
+
+    // Execution
+    err := p.Parse([]string { "a", "b", "c" })
+
+    // Assertions
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    if !reflect.DeepEqual(*list, []string { "a", "b", "c" }) { t.Errorf("Unexpected list: %v", *list) }
+}
+
+func TestMissingArgumentNamesFirstUnfilledSpec(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    p.StringList("sources", false, nil)
+    p.String("dest", false, nil)
+
+    // Execution
+    err := p.Parse([]string { "a" })
+
+    // Assertions
+    if err == nil || err.Error() != `Missing required argument "dest"` {
+        t.Errorf("Unexpected error: %v", err)
+    }
+}
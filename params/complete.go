@@ -0,0 +1,146 @@
+package params
+
+import (
+    "errors"
+    "io/ioutil"
+    "sort"
+    "strings"
+)
+
+// Completer can be implemented by a ParamSpec or its Value to suggest completions of a partially
+// typed argument, for example in an interactive shell.
+type Completer interface {
+
+    // Complete returns the possible values of the argument that start with prefix.
+    Complete(prefix string) []string
+}
+
+// Complete returns the completions of the last argument of argv, which is the partially typed
+// argument and can be empty. The arguments are allocated to the ParamSpecs like Parse, to find the
+// ParamSpec that receives the last argument, and its Completer is asked for completions. Nil is
+// returned if the ParamSpec is hidden or not a Completer, or if the arguments cannot be allocated.
+func (ps *ParamSet) Complete(argv []string) []string {
+    if ps == nil || len(argv) == 0 {
+        return nil
+    }
+    paramSpec := ps.specAt(argv, len(argv) - 1)
+    if paramSpec == nil || IsHidden(paramSpec) {
+        return nil
+    }
+    completer, ok := paramSpec.(Completer)
+    if !ok {
+        return nil
+    }
+    return completer.Complete(argv[len(argv) - 1])
+}
+
+// specAt returns the ParamSpec that the argument at index would be assigned to. Since argv may be
+// incomplete, empty arguments are added until the required ParamSpecs after it can be allocated.
+func (ps *ParamSet) specAt(argv []string, index int) ParamSpec {
+    minArgCount := 0
+    for _, paramSpec := range *ps {
+        minArgCount += paramSpec.MinLength()
+    }
+    padded := append([]string {}, argv...)
+    for {
+        lengths, err := ps.allocate(padded, true, nil)
+        if err == nil {
+            end := 0
+            for i, l := range lengths {
+                end += l
+                if index < end {
+                    return (*ps)[i]
+                }
+            }
+            return nil
+        }
+        if !errors.Is(err, ErrMissingArgument) || len(padded) >= len(argv) + minArgCount {
+            return nil
+        }
+        padded = append(padded, "")
+    }
+}
+
+// Complete returns the completions of the value if it implements Completer.
+func (param *commonParamSpec) Complete(prefix string) []string {
+    var target interface{} = param.value
+    if vc, ok := target.(valueContainer); ok {
+        target = vc.value
+    }
+    if completer, ok := target.(Completer); ok {
+        return completer.Complete(prefix)
+    }
+    return nil
+}
+
+// completeWords returns the words that start with prefix
+func completeWords(prefix string, words []string) []string {
+    var completions []string
+    for _, word := range words {
+        if strings.HasPrefix(word, prefix) {
+            completions = append(completions, word)
+        }
+    }
+    return completions
+}
+
+// CompleteFiles returns the paths of the files that start with prefix, in the directory of the
+// prefix. Directories end with a "/", so that their contents can be completed next. If dirsOnly is
+// true, only directories are returned. Files starting with "." are only returned if the prefix of
+// their name starts with ".".
+func CompleteFiles(prefix string, dirsOnly bool) []string {
+    dir, base := "", prefix
+    if i := strings.LastIndex(prefix, "/"); i >= 0 {
+        dir, base = prefix[:i + 1], prefix[i + 1:]
+    }
+    readDir := dir
+    if readDir == "" { readDir = "." }
+    readDir, err := expandHome(readDir)
+    if err != nil { return nil }
+    infos, err := ioutil.ReadDir(readDir)
+    if err != nil { return nil }
+    var completions []string
+    for _, info := range infos {
+        name := info.Name()
+        if !strings.HasPrefix(name, base) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".")) {
+            continue
+        }
+        if info.IsDir() {
+            completions = append(completions, dir + name + "/")
+        } else if !dirsOnly {
+            completions = append(completions, dir + name)
+        }
+    }
+    sort.Strings(completions)
+    return completions
+}
+
+// Complete returns the choices that start with prefix.
+func (c *ChoiceValue) Complete(prefix string) []string {
+    return completeWords(prefix, c.choices)
+}
+
+// Complete returns the files that start with prefix.
+func (f *InputFileValue) Complete(prefix string) []string {
+    return CompleteFiles(prefix, false)
+}
+
+// Complete returns the files that start with prefix.
+func (list *InputFileValueList) Complete(prefix string) []string {
+    return CompleteFiles(prefix, false)
+}
+
+// Complete returns the files that start with prefix.
+func (f *OutputFileValue) Complete(prefix string) []string {
+    return CompleteFiles(prefix, false)
+}
+
+// Complete returns the directories that start with prefix.
+func (d *DirValue) Complete(prefix string) []string {
+    return CompleteFiles(prefix, true)
+}
+
+// Complete returns the values of a BoolValue that start with prefix.
+func (b *BoolValue) Complete(prefix string) []string {
+    return completeWords(prefix, []string { "false", "true" })
+}
@@ -0,0 +1,73 @@
+package params
+
+import (
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "reflect"
+    "testing"
+)
+
+func TestCompleteAllocatedParam(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    p.StringList("files", false, nil)
+    p.Choice("mode", []string { "fast", "faster", "slow" }, false, nil)
+
+    // Test execution
+
+    first := p.Complete([]string { "f" })
+    last := p.Complete([]string { "a", "b", "fa" })
+
+    // Assertions
+
+    if first != nil { t.Errorf("The first argument is a file list, but was completed with %q", first) }
+    if !reflect.DeepEqual(last, []string { "fast", "faster" }) { t.Errorf("Unexpected completions: %q", last) }
+}
+
+func TestCompleteSkipsHiddenParams(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    var mode string
+    p.Param(Hidden(NewValueParamSpec(NewChoiceValue("", &mode, []string { "fast", "slow" }), "mode", false, nil)))
+    p.Choice("speed", []string { "fast", "slow" }, true, nil)
+
+    // Test execution
+
+    hidden := p.Complete([]string { "f" })
+    visible := p.Complete([]string { "fast", "s" })
+
+    // Assertions
+
+    if hidden != nil { t.Errorf("Hidden params should not be completed, but were completed with %q", hidden) }
+    if !reflect.DeepEqual(visible, []string { "slow" }) { t.Errorf("Unexpected completions: %q", visible) }
+}
+
+func TestCompleteFiles(t *testing.T) {
+
+    // Test setup
+
+    dir, err := ioutil.TempDir("", "complete")
+    if err != nil { t.Fatal(err) }
+    defer os.RemoveAll(dir)
+    os.Mkdir(filepath.Join(dir, "sub"), 0755)
+    for _, name := range []string { "script.sed", "sub/inner", ".hidden" } {
+        ioutil.WriteFile(filepath.Join(dir, name), nil, 0644)
+    }
+
+    // Test execution
+
+    all := CompleteFiles(dir + "/", false)
+    dirs := CompleteFiles(dir + "/s", true)
+    hidden := CompleteFiles(dir + "/.h", false)
+
+    // Assertions
+
+    if !reflect.DeepEqual(all, []string { dir + "/script.sed", dir + "/sub/" }) { t.Errorf("Unexpected files: %q", all) }
+    if !reflect.DeepEqual(dirs, []string { dir + "/sub/" }) { t.Errorf("Unexpected directories: %q", dirs) }
+    if !reflect.DeepEqual(hidden, []string { dir + "/.hidden" }) { t.Errorf("Unexpected hidden files: %q", hidden) }
+}
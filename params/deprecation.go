@@ -0,0 +1,210 @@
+package params
+
+import (
+    "io"
+    "log"
+    "os"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+// Logger receives the warnings of this package, like the use of deprecated parameters. A
+// *log.Logger can be used as a Logger.
+type Logger interface {
+    Printf(format string, v ...interface{})
+}
+
+var logger Logger = log.New(os.Stderr, "", 0)
+
+// SetLogger sets the Logger that receives warnings, which by default are printed to stderr. If the
+// logger is nil, warnings are discarded.
+func SetLogger(l Logger) {
+    logger = l
+}
+
+func warnf(format string, a ...interface{}) {
+    if logger != nil {
+        logger.Printf(format, a...)
+    }
+}
+
+// DeprecatedSpec can optionally be implemented by a ParamSpec that is deprecated. A warning is
+// logged when the deprecated parameter receives any values during Parse. The Deprecated field of
+// Help has the same effect.
+type DeprecatedSpec interface {
+    // Deprecation returns the deprecation message, or an empty string if it is not deprecated
+    Deprecation() string
+}
+
+// HiddenSpec can optionally be implemented by a ParamSpec that is hidden from the usage and the
+// help.
+type HiddenSpec interface {
+    Hidden() bool
+}
+
+// RenamedSpec can optionally be implemented by a ParamSpec that was renamed, so that it can still
+// be looked up by its old names.
+type RenamedSpec interface {
+    OldNames() []string
+}
+
+// annotatedParamSpec adds the deprecated, hidden and renamed annotations to a ParamSpec, while
+// forwarding the optional interfaces of the ParamSpec
+type annotatedParamSpec struct {
+    ParamSpec
+    deprecation string
+    hidden bool
+    oldNames []string
+}
+
+var _ DeprecatedSpec = (*annotatedParamSpec)(nil)
+var _ HiddenSpec = (*annotatedParamSpec)(nil)
+var _ RenamedSpec = (*annotatedParamSpec)(nil)
+
+func annotate(paramSpec ParamSpec) *annotatedParamSpec {
+    if annotated, ok := paramSpec.(*annotatedParamSpec); ok {
+        copied := *annotated
+        return &copied
+    }
+    return &annotatedParamSpec{ ParamSpec: paramSpec }
+}
+
+func (param *annotatedParamSpec) Deprecation() string {
+    return param.deprecation
+}
+
+func (param *annotatedParamSpec) Hidden() bool {
+    return param.hidden
+}
+
+func (param *annotatedParamSpec) OldNames() []string {
+    return param.oldNames
+}
+
+func (param *annotatedParamSpec) Synopsis() string {
+    return SpecSynopsis(param.ParamSpec)
+}
+
+func (param *annotatedParamSpec) ReserveLength(argvSlice []string) int {
+    if reserver, ok := param.ParamSpec.(Reserver); ok {
+        return reserver.ReserveLength(argvSlice)
+    }
+    return 0
+}
+
+func (param *annotatedParamSpec) Reset(policy ResetPolicy) {
+    if resetter, ok := param.ParamSpec.(Resetter); ok {
+        resetter.Reset(policy)
+    }
+}
+
+func (param *annotatedParamSpec) Commit() error {
+    if committer, ok := param.ParamSpec.(Committer); ok {
+        return committer.Commit()
+    }
+    return nil
+}
+
+func (param *annotatedParamSpec) Complete(prefix string) []string {
+    if completer, ok := param.ParamSpec.(Completer); ok {
+        return completer.Complete(prefix)
+    }
+    return nil
+}
+
+func (param *annotatedParamSpec) Close() error {
+    if closer, ok := param.ParamSpec.(io.Closer); ok {
+        return closer.Close()
+    }
+    return nil
+}
+
+// Unwrap returns the annotated ParamSpec.
+func (param *annotatedParamSpec) Unwrap() ParamSpec {
+    return param.ParamSpec
+}
+
+// Deprecated marks the ParamSpec as deprecated. When it receives any values, a warning with the
+// message is logged to the Logger.
+func Deprecated(paramSpec ParamSpec, message string) ParamSpec {
+    annotated := annotate(paramSpec)
+    annotated.deprecation = message
+    return annotated
+}
+
+// Hidden hides the ParamSpec from the usage and the help. It is still parsed as usual.
+func Hidden(paramSpec ParamSpec) ParamSpec {
+    annotated := annotate(paramSpec)
+    annotated.hidden = true
+    return annotated
+}
+
+// Renamed records the old names of the ParamSpec, so that it can still be found by Lookup using
+// its old names.
+func Renamed(paramSpec ParamSpec, oldNames ...string) ParamSpec {
+    annotated := annotate(paramSpec)
+    annotated.oldNames = append(append([]string {}, annotated.oldNames...), oldNames...)
+    return annotated
+}
+
+// IsHidden returns whether the ParamSpec is hidden from the usage and the help.
+func IsHidden(paramSpec ParamSpec) bool {
+    hidden, ok := paramSpec.(HiddenSpec)
+    return ok && hidden.Hidden()
+}
+
+// Lookup returns the ParamSpec with the given name. If the name is an old name of a renamed
+// ParamSpec, a warning is logged and the renamed ParamSpec is returned. Lookup returns nil if no
+// ParamSpec has the name.
+func (ps *ParamSet) Lookup(name string) ParamSpec {
+    if ps == nil {
+        return nil
+    }
+    for _, paramSpec := range *ps {
+        if paramSpec.String() == name {
+            return paramSpec
+        }
+    }
+    for _, paramSpec := range *ps {
+        if renamed, ok := paramSpec.(RenamedSpec); ok {
+            for _, oldName := range renamed.OldNames() {
+                if oldName == name {
+                    warnf("%s", i18n.Sprintf(i18n.RenamedArgument, name, paramSpec.String()))
+                    return paramSpec
+                }
+            }
+        }
+    }
+    return nil
+}
+
+// Migration describes an old layout of the positional arguments that is still accepted for a
+// while. The specs of the Legacy ParamSet typically receive their values into the same variables
+// as the current ParamSet, e.g. using VarValue.
+type Migration struct {
+    Legacy *ParamSet
+    // Message is logged as a warning when argv is parsed using the legacy layout
+    Message string
+}
+
+// ParseMigrating parses argv like Parse. If the arguments cannot be allocated to the ParamSet, each
+// of the legacy layouts is tried in order, and the first one that can allocate them is used, with
+// its warning logged. If none of them can, the error of the current ParamSet is returned.
+func (ps *ParamSet) ParseMigrating(argv []string, migrations ...Migration) error {
+    if ps == nil {
+        return nil
+    }
+    if _, err := ps.allocate(argv, false, nil); err != nil {
+        for _, migration := range migrations {
+            if migration.Legacy == nil {
+                continue
+            }
+            if _, legacyErr := migration.Legacy.allocate(argv, false, nil); legacyErr == nil {
+                warnf("%s", i18n.Sprintf(i18n.Warning, migration.Message))
+                return migration.Legacy.Parse(argv)
+            }
+        }
+        return err
+    }
+    return ps.Parse(argv)
+}
@@ -0,0 +1,140 @@
+package params
+
+import (
+    "fmt"
+    "reflect"
+    "testing"
+)
+
+type recordingLogger []string
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+    *l = append(*l, fmt.Sprintf(format, v...))
+}
+
+func useRecordingLogger() (*recordingLogger, func()) {
+    l := new(recordingLogger)
+    SetLogger(l)
+    return l, func() { SetLogger(nil) }
+}
+
+func TestDeprecatedParam(t *testing.T) {
+
+    // Test setup
+
+    warnings, restore := useRecordingLogger()
+    defer restore()
+    p := new(ParamSet)
+    var source string
+    p.Param(Deprecated(NewValueParamSpec(NewStringValue("", &source), "source", true, nil), "use --source instead"))
+
+    // Test execution
+
+    err := p.Parse([]string { "a" })
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if source != "a" { t.Errorf(`source should be "a", but was %s`, source) }
+    expected := []string { `Warning: argument "source" is deprecated: use --source instead` }
+    if !reflect.DeepEqual([]string(*warnings), expected) { t.Errorf("Unexpected warnings: %v", *warnings) }
+}
+
+func TestDeprecatedParamNotUsed(t *testing.T) {
+    // Setup
+    warnings, restore := useRecordingLogger()
+    defer restore()
+    p := new(ParamSet)
+    p.Param(Deprecated(NewListParamSpec(new(StringValueList), "old", true, nil), "unused"))
+
+    // Execution
+    err := p.Parse([]string {})
+
+    // Assertions
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    if len(*warnings) != 0 { t.Errorf("Unexpected warnings: %v", *warnings) }
+}
+
+func TestHiddenParamDefaults(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    files := &StringValueList{ "." }
+    p.Param(Hidden(NewListParamSpec(files, "files", true, nil)))
+
+    // Test execution
+
+    args, err1 := p.MarshalArgs()
+    recording, err2 := p.ParseRecord(args)
+
+    // Assertions
+
+    if err1 != nil || err2 != nil { t.Errorf("Errors should be nil, but were %v, %v", err1, err2) }
+    if len(args) != 0 { t.Errorf("Defaults should be omitted, but args were %q", args) }
+    if !reflect.DeepEqual(*files, StringValueList { "." }) { t.Errorf(`files should be ["."], but was %q`, *files) }
+    if source := recording.Params[0].Source; source != SourceDefault { t.Errorf("files should have the default source, but was %s", source) }
+}
+
+func TestHiddenAndRenamedParams(t *testing.T) {
+    // Setup
+    warnings, restore := useRecordingLogger()
+    defer restore()
+    p := new(ParamSet)
+    p.String("command", false, nil)
+    p.Param(Hidden(Renamed(NewMapParamSpec(new(StringValueMap), "vars", true, nil), "env")))
+
+    // Assertions
+    if synopsis := p.Synopsis(); synopsis != "<command>" { t.Errorf("Unexpected synopsis: %s", synopsis) }
+    if spec := p.Lookup("env"); spec == nil || spec.String() != "vars" { t.Errorf("Unexpected spec: %v", spec) }
+    if !reflect.DeepEqual([]string(*warnings), []string { `Warning: argument "env" was renamed to "vars"` }) {
+        t.Errorf("Unexpected warnings: %v", *warnings)
+    }
+    if p.Lookup("missing") != nil { t.Errorf("Lookup should return nil") }
+
+    // Annotated specs still reserve their arguments
+    list := p.StringList("files", true, nil)
+    (*p)[1], (*p)[2] = (*p)[2], (*p)[1]
+    err := p.Parse([]string { "run", "a", "X=1" })
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    if !reflect.DeepEqual(*list, []string { "a" }) { t.Errorf("Unexpected list: %v", *list) }
+}
+
+func TestParseMigrating(t *testing.T) {
+
+    // Test setup
+
+    warnings, restore := useRecordingLogger()
+    defer restore()
+
+    // The old layout was "<dst> <src> <count>", the new layout is "<src> <dst>"
+    var src, dst string
+    var count int
+    p := new(ParamSet)
+    p.VarValue(NewStringValue("", &src), "src", false, nil)
+    p.VarValue(NewStringValue("", &dst), "dst", false, nil)
+    legacy := new(ParamSet)
+    legacy.VarValue(NewStringValue("", &dst), "dst", false, nil)
+    legacy.VarValue(NewStringValue("", &src), "src", false, nil)
+    legacy.VarValue(NewIntValue(0, &count), "count", false, nil)
+    migration := Migration{ legacy, "<count> will be removed in the next release" }
+
+    // Test execution
+
+    err := p.ParseMigrating([]string { "a", "b", "3" }, migration)
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if src != "b" || dst != "a" || count != 3 { t.Errorf("Unexpected values: %s %s %d", src, dst, count) }
+    if !reflect.DeepEqual([]string(*warnings), []string { "Warning: <count> will be removed in the next release" }) {
+        t.Errorf("Unexpected warnings: %v", *warnings)
+    }
+
+    err = p.ParseMigrating([]string { "c", "d" }, migration)
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if src != "c" || dst != "d" { t.Errorf("Unexpected values: %s %s", src, dst) }
+
+    err = p.ParseMigrating([]string { "c" }, migration)
+    if err == nil { t.Errorf("Error should not be nil") }
+}
@@ -1,15 +1,89 @@
 package params
 
-import "fmt"
+import (
+    "errors"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+// The kinds of ArgumentError, which can be tested with errors.Is.
+var (
+    // ErrMissingArgument is the kind of error when a required parameter has no arguments
+    ErrMissingArgument = errors.New("missing argument")
+    // ErrTooManyArguments is the kind of error when arguments are left after all the parameters
+    ErrTooManyArguments = errors.New("too many arguments")
+    // ErrConversion is the kind of error when a Value cannot convert an argument
+    ErrConversion = errors.New("conversion error")
+    // ErrValidation is the kind of error when an argument is well-formed but not acceptable, for
+    // example an invalid choice or a file that is a directory. Values can return errors for which
+    // errors.Is(err, ErrValidation) is true to report validation errors instead of conversion errors.
+    ErrValidation = errors.New("validation error")
+)
+
+// ExitCodes are the recommended exit codes of the kinds of ArgumentError. By default, all of them
+// are 2, the exit code of usage errors, but programs can map them to e.g. the codes of sysexits.h.
+var ExitCodes = map[error]int {
+    ErrMissingArgument: 2,
+    ErrTooManyArguments: 2,
+    ErrConversion: 2,
+    ErrValidation: 2,
+}
 
 // ArgumentError is the error returned when arguments cannot be assigned correctly according to the
 // specifications
 type ArgumentError struct {
     err error
+
+    // Kind is one of ErrMissingArgument, ErrTooManyArguments, ErrConversion and ErrValidation
+    Kind error
+    // Param is the name of the parameter that the error is about, empty for ErrTooManyArguments
+    Param string
+    // Args are the arguments that caused the error: the arguments that could not be converted or
+    // validated, or the remaining arguments for ErrTooManyArguments
+    Args []string
+    // Suggestions are the values the invalid argument may have been meant to be, if the cause of
+    // the error is a SuggestionError
+    Suggestions []string
+}
+
+// argumentError creates an ArgumentError of the kind with the message translated to the current
+// locale
+func argumentError(kind error, param string, id i18n.MessageID, a ...interface{}) *ArgumentError {
+    return &ArgumentError{ err: i18n.Errorf(id, a...), Kind: kind, Param: param }
 }
 
-func argumentErrorf(format string, a ...interface{}) *ArgumentError {
-    return &ArgumentError{ fmt.Errorf(format, a...) }
+// NewArgumentError creates an ArgumentError of the kind for the parameter, caused by err. If kind is
+// nil, it is ErrValidation if errors.Is(err, ErrValidation) or err is a SuggestionError, and
+// ErrConversion otherwise. If a SuggestionError is in the chain of causes of err, its suggestions
+// are copied to the ArgumentError.
+func NewArgumentError(kind error, param string, err error) *ArgumentError {
+    a := &ArgumentError{ err: err, Kind: kind, Param: param }
+    for cause := err; cause != nil; {
+        if s, ok := cause.(*SuggestionError); ok {
+            a.Suggestions = s.Suggestions
+            break
+        }
+        causer, ok := cause.(interface{ Cause() error })
+        if !ok { break }
+        cause = causer.Cause()
+    }
+    if a.Kind == nil {
+        a.Kind = ErrConversion
+        if a.Suggestions != nil || errors.Is(err, ErrValidation) {
+            a.Kind = ErrValidation
+        }
+    }
+    return a
+}
+
+// toArgumentError returns err as an ArgumentError about the param and args. An ArgumentError is
+// returned as is, with its Param and Args filled in if they are empty.
+func toArgumentError(err error, param string, args []string) *ArgumentError {
+    a, ok := err.(*ArgumentError)
+    if !ok { a = NewArgumentError(nil, param, err) }
+    if a.Param == "" { a.Param = param }
+    if a.Args == nil { a.Args = args }
+    return a
 }
 
 func (a *ArgumentError) Error() string {
@@ -20,3 +94,55 @@ func (a *ArgumentError) Error() string {
 func (a *ArgumentError) Cause() error {
     return a.err
 }
+
+// Unwrap returns the cause of the error, for errors.Is and errors.As.
+func (a *ArgumentError) Unwrap() error {
+    return a.err
+}
+
+// Is reports whether target is the Kind of the error, so that e.g.
+// errors.Is(err, ErrMissingArgument) can be used to test the kind of an error returned by Parse.
+func (a *ArgumentError) Is(target error) bool {
+    return a.Kind != nil && target == a.Kind
+}
+
+// ExitCode returns the recommended exit code for the Kind of the error from ExitCodes, or 2 if
+// the kind is not in ExitCodes.
+func (a *ArgumentError) ExitCode() int {
+    if code, ok := ExitCodes[a.Kind]; ok {
+        return code
+    }
+    return 2
+}
+
+// ExitCode returns the recommended exit code for an error returned by Parse: 0 for nil, the
+// ExitCode of an ArgumentError in the chain of err, and 1 otherwise.
+func ExitCode(err error) int {
+    if err == nil {
+        return 0
+    }
+    var argErr *ArgumentError
+    if errors.As(err, &argErr) {
+        return argErr.ExitCode()
+    }
+    return 1
+}
+
+// validationError is an error of a Value for an argument that is well-formed but not acceptable
+type validationError struct {
+    message string
+}
+
+// validationErrorf creates an error with the formatted message for which
+// errors.Is(err, ErrValidation) is true
+func validationErrorf(id i18n.MessageID, a ...interface{}) error {
+    return &validationError{ i18n.Sprintf(id, a...) }
+}
+
+func (e *validationError) Error() string {
+    return e.message
+}
+
+func (e *validationError) Is(target error) bool {
+    return target == ErrValidation
+}
@@ -0,0 +1,138 @@
+package params
+
+import (
+    "errors"
+    "reflect"
+    "regexp"
+    "strconv"
+    "strings"
+    "testing"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+func TestErrorKinds(t *testing.T) {
+
+    // Test setup
+
+    newParamSet := func() *ParamSet {
+        p := new(ParamSet)
+        p.Int("count", false, nil)
+        p.Choice("mode", []string { "fast", "slow" }, true, nil)
+        return p
+    }
+
+    cases := []struct {
+        name string
+        argv []string
+        kind error
+        param string
+        args []string
+    }{
+        { "missing", []string {}, ErrMissingArgument, "count", nil },
+        { "too many", []string { "1", "fast", "x", "y" }, ErrTooManyArguments, "", []string { "x", "y" } },
+        { "conversion", []string { "one" }, ErrConversion, "count", []string { "one" } },
+        { "validation", []string { "1", "fsat" }, ErrValidation, "mode", []string { "fsat" } },
+    }
+    for _, c := range cases {
+        t.Run(c.name, func (t *testing.T) {
+
+            // Test execution
+
+            err := newParamSet().Parse(c.argv)
+
+            // Assertions
+
+            if !errors.Is(err, c.kind) { t.Fatalf("Error should be %v, but was %#v", c.kind, err) }
+            for _, kind := range []error { ErrMissingArgument, ErrTooManyArguments, ErrConversion, ErrValidation } {
+                if kind != c.kind && errors.Is(err, kind) { t.Errorf("Error should not be %v", kind) }
+            }
+            var argErr *ArgumentError
+            if !errors.As(err, &argErr) { t.Fatalf("Error should be an ArgumentError, but was %T", err) }
+            if argErr.Param != c.param { t.Errorf("Param should be %q, but was %q", c.param, argErr.Param) }
+            if !reflect.DeepEqual(argErr.Args, c.args) { t.Errorf("Args should be %q, but was %q", c.args, argErr.Args) }
+            if ExitCode(err) != 2 { t.Errorf("Exit code should be 2, but was %d", ExitCode(err)) }
+        })
+    }
+}
+
+func TestPatternErrorKinds(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    p.StringPattern("commit", regexp.MustCompile(`^[0-9a-f]{7,40}$`), false, nil)
+
+    // Test execution
+
+    err := p.Parse([]string { "main" })
+
+    // Assertions
+
+    var argErr *ArgumentError
+    if !errors.As(err, &argErr) || argErr.Kind != ErrValidation {
+        t.Fatalf("Error should be a validation error, but was %#v", err)
+    }
+    if argErr.Param != "commit" || !reflect.DeepEqual(argErr.Args, []string { "main" }) {
+        t.Errorf(`Error should be about "main" for commit, but was %q %q`, argErr.Param, argErr.Args)
+    }
+}
+
+func TestValueValidationErrors(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    p.StringMap("env", RejectDuplicates, true, nil)
+
+    // Test execution
+
+    err := p.Parse([]string { "a=1", "a=2" })
+
+    // Assertions
+
+    if !errors.Is(err, ErrValidation) { t.Errorf("Duplicate key should be a validation error, but was %#v", err) }
+}
+
+func TestLocalizedValueErrors(t *testing.T) {
+
+    // Test setup
+
+    i18n.SetLocale("de")
+    defer i18n.SetLocale("en")
+    p := new(ParamSet)
+    p.StringMap("env", RejectDuplicates, true, nil)
+    q := new(ParamSet)
+    var pairs []copyPair
+    q.GroupList(&pairs, "pairs", false, nil)
+
+    // Test execution
+
+    err1 := p.Parse([]string { "a=1", "a=2" })
+    err2 := q.Parse([]string { "a", "b", "x" })
+
+    // Assertions
+
+    if err1 == nil || !strings.Contains(err1.Error(), `Doppelter Schlüssel "a"`) { t.Errorf("Error should be translated, but was %v", err1) }
+    if err2 == nil || !strings.Contains(err2.Error(), `Gruppe 1, Feld "mode"`) { t.Errorf("Error should be translated, but was %v", err2) }
+    var numErr *strconv.NumError
+    if !errors.As(err2, &numErr) { t.Errorf("Translated error should wrap the conversion error, but was %#v", err2) }
+}
+
+func TestExitCodes(t *testing.T) {
+
+    // Test setup
+
+    defer func(codes map[error]int) { ExitCodes = codes }(ExitCodes)
+    ExitCodes = map[error]int { ErrMissingArgument: 64, ErrConversion: 65 }
+    p := new(ParamSet)
+    p.Int("count", false, nil)
+
+    // Assertions
+
+    if code := ExitCode(p.Parse([]string {})); code != 64 { t.Errorf("Exit code should be 64, but was %d", code) }
+    if code := ExitCode(p.Parse([]string { "x" })); code != 65 { t.Errorf("Exit code should be 65, but was %d", code) }
+    if code := ExitCode(p.Parse([]string { "1", "2" })); code != 2 { t.Errorf("Exit code should be 2, but was %d", code) }
+    if code := ExitCode(nil); code != 0 { t.Errorf("Exit code should be 0, but was %d", code) }
+    if code := ExitCode(errors.New("other")); code != 1 { t.Errorf("Exit code should be 1, but was %d", code) }
+}
@@ -0,0 +1,403 @@
+package params
+
+import (
+    "fmt"
+    "math/big"
+    "net"
+    "net/url"
+    "os"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+// -- url.URL Value
+type URLValue url.URL
+
+func NewURLValue(val url.URL, p *url.URL) *URLValue {
+    *p = val
+    return (*URLValue)(p)
+}
+
+func (u *URLValue) Set(s string) error {
+    v, err := url.Parse(s)
+    if err != nil { return err }
+    *u = URLValue(*v)
+    return nil
+}
+
+func (u *URLValue) Get() interface{} { return (*url.URL)(u) }
+
+func (u *URLValue) String() string { return (*url.URL)(u).String() }
+
+// -- net.IP Value
+type IPValue net.IP
+
+func NewIPValue(val net.IP, p *net.IP) *IPValue {
+    *p = val
+    return (*IPValue)(p)
+}
+
+func (ip *IPValue) Set(s string) error {
+    v := net.ParseIP(s)
+    if v == nil { return i18n.Errorf(i18n.InvalidIP, s) }
+    *ip = IPValue(v)
+    return nil
+}
+
+func (ip *IPValue) Get() interface{} { return net.IP(*ip) }
+
+func (ip *IPValue) String() string {
+    if *ip == nil { return "" }
+    return net.IP(*ip).String()
+}
+
+// -- net.IPNet Value
+type IPNetValue net.IPNet
+
+func NewIPNetValue(val net.IPNet, p *net.IPNet) *IPNetValue {
+    *p = val
+    return (*IPNetValue)(p)
+}
+
+func (n *IPNetValue) Set(s string) error {
+    _, v, err := net.ParseCIDR(s)
+    if err != nil { return err }
+    *n = IPNetValue(*v)
+    return nil
+}
+
+func (n *IPNetValue) Get() interface{} { return (*net.IPNet)(n) }
+
+func (n *IPNetValue) String() string {
+    if n.IP == nil { return "" }
+    return (*net.IPNet)(n).String()
+}
+
+// -- *regexp.Regexp Value. The compiled regexp is replaced rather than copied, since a
+// regexp.Regexp must not be copied.
+type RegexpValue struct {
+    regexp **regexp.Regexp
+    defaultRegexp *regexp.Regexp
+}
+
+func NewRegexpValue(val *regexp.Regexp, p **regexp.Regexp) *RegexpValue {
+    *p = val
+    return &RegexpValue{ p, val }
+}
+
+// Reset sets the regexp back to the val passed to NewRegexpValue, or to nil for ClearValues.
+func (r *RegexpValue) Reset(policy ResetPolicy) {
+    *r.regexp = r.defaultRegexp
+    if policy == ClearValues { *r.regexp = nil }
+}
+
+func (r *RegexpValue) Set(s string) error {
+    v, err := regexp.Compile(s)
+    if err != nil { return err }
+    *r.regexp = v
+    return nil
+}
+
+func (r *RegexpValue) Get() interface{} { return *r.regexp }
+
+func (r *RegexpValue) String() string {
+    if *r.regexp == nil { return "" }
+    return (*r.regexp).String()
+}
+
+// ByteSize is a number of bytes, which is parsed from human readable sizes like "10MiB" or "1.5G".
+// As in GNU coreutils, the single letter suffixes (K, M, G, T, P, E) and the binary suffixes (KiB,
+// MiB...) are powers of 1024, while the decimal suffixes (kB, MB...) are powers of 1000.
+type ByteSize uint64
+
+var byteSizeUnits = []string { "K", "M", "G", "T", "P", "E" }
+
+// ParseByteSize parses a human readable size like "10MiB" into a ByteSize.
+func ParseByteSize(s string) (ByteSize, error) {
+    trimmed := strings.TrimSpace(s)
+    i := strings.IndexFunc(trimmed, func(r rune) bool {
+        return !(r >= '0' && r <= '9' || r == '.')
+    })
+    if i < 0 { i = len(trimmed) }
+    number, unit := trimmed[:i], strings.TrimSpace(trimmed[i:])
+    multiplier, ok := byteSizeMultiplier(unit)
+    if !ok || number == "" {
+        return 0, i18n.Errorf(i18n.InvalidByteSize, s)
+    }
+    if n, err := strconv.ParseUint(number, 10, 64); err == nil {
+        if multiplier != 0 && n > ^uint64(0) / multiplier {
+            return 0, i18n.Errorf(i18n.ByteSizeOutOfRange, s)
+        }
+        return ByteSize(n * multiplier), nil
+    }
+    f, err := strconv.ParseFloat(number, 64)
+    if err != nil { return 0, i18n.Errorf(i18n.InvalidByteSize, s) }
+    if f * float64(multiplier) >= 18446744073709551616.0 {
+        return 0, i18n.Errorf(i18n.ByteSizeOutOfRange, s)
+    }
+    return ByteSize(f * float64(multiplier)), nil
+}
+
+func byteSizeMultiplier(unit string) (uint64, bool) {
+    if unit == "" || strings.EqualFold(unit, "B") {
+        return 1, true
+    }
+    for i, u := range byteSizeUnits {
+        binary := uint64(1) << (10 * uint(i + 1))
+        decimal := uint64(1)
+        for j := 0; j <= i; j++ { decimal *= 1000 }
+        switch {
+        case strings.EqualFold(unit, u), strings.EqualFold(unit, u + "iB"):
+            return binary, true
+        case strings.EqualFold(unit, u + "B"):
+            return decimal, true
+        }
+    }
+    return 0, false
+}
+
+// String formats the size using the largest binary unit that represents it exactly, e.g. "10MiB".
+func (b ByteSize) String() string {
+    n := uint64(b)
+    unit := ""
+    for _, u := range byteSizeUnits {
+        if n == 0 || n % 1024 != 0 { break }
+        n /= 1024
+        unit = u + "iB"
+    }
+    if unit == "" { unit = "B" }
+    return strconv.FormatUint(n, 10) + unit
+}
+
+// -- ByteSize Value
+type SizeValue ByteSize
+
+func NewSizeValue(val ByteSize, p *ByteSize) *SizeValue {
+    *p = val
+    return (*SizeValue)(p)
+}
+
+func (b *SizeValue) Set(s string) error {
+    v, err := ParseByteSize(s)
+    if err != nil { return err }
+    *b = SizeValue(v)
+    return nil
+}
+
+func (b *SizeValue) Get() interface{} { return ByteSize(*b) }
+
+func (b *SizeValue) String() string { return ByteSize(*b).String() }
+
+// -- os.FileMode Value, parsed and formatted as an octal number like 0644
+type FileModeValue os.FileMode
+
+func NewFileModeValue(val os.FileMode, p *os.FileMode) *FileModeValue {
+    *p = val
+    return (*FileModeValue)(p)
+}
+
+func (m *FileModeValue) Set(s string) error {
+    v, err := strconv.ParseUint(s, 8, 32)
+    if err != nil { return err }
+    if v > 07777 { return i18n.Errorf(i18n.InvalidFileMode, s) }
+    *m = FileModeValue(v & 0777)
+    if v & 04000 != 0 { *m |= FileModeValue(os.ModeSetuid) }
+    if v & 02000 != 0 { *m |= FileModeValue(os.ModeSetgid) }
+    if v & 01000 != 0 { *m |= FileModeValue(os.ModeSticky) }
+    return nil
+}
+
+func (m *FileModeValue) Get() interface{} { return os.FileMode(*m) }
+
+func (m *FileModeValue) String() string {
+    mode := os.FileMode(*m)
+    v := uint32(mode.Perm())
+    if mode & os.ModeSetuid != 0 { v |= 04000 }
+    if mode & os.ModeSetgid != 0 { v |= 02000 }
+    if mode & os.ModeSticky != 0 { v |= 01000 }
+    return fmt.Sprintf("%#o", v)
+}
+
+// -- *big.Int Value. A new big.Int is allocated for every value, since a big.Int must not be
+// copied.
+type BigIntValue struct {
+    value **big.Int
+    defaultValue *big.Int
+}
+
+func NewBigIntValue(val *big.Int, p **big.Int) *BigIntValue {
+    *p = val
+    return &BigIntValue{ p, val }
+}
+
+// Reset sets the value back to a copy of the val passed to NewBigIntValue, or to nil for
+// ClearValues.
+func (i *BigIntValue) Reset(policy ResetPolicy) {
+    *i.value = nil
+    if i.defaultValue != nil && policy != ClearValues { *i.value = new(big.Int).Set(i.defaultValue) }
+}
+
+func (i *BigIntValue) Set(s string) error {
+    v, ok := new(big.Int).SetString(s, 0)
+    if !ok {
+        return i18n.Errorf(i18n.InvalidInteger, s)
+    }
+    *i.value = v
+    return nil
+}
+
+func (i *BigIntValue) Get() interface{} { return *i.value }
+
+func (i *BigIntValue) String() string {
+    if *i.value == nil { return "" }
+    return (*i.value).String()
+}
+
+// -- *big.Float Value. A new big.Float is allocated for every value, since a big.Float must not be
+// copied.
+type BigFloatValue struct {
+    value **big.Float
+    defaultValue *big.Float
+}
+
+func NewBigFloatValue(val *big.Float, p **big.Float) *BigFloatValue {
+    *p = val
+    return &BigFloatValue{ p, val }
+}
+
+// Reset sets the value back to a copy of the val passed to NewBigFloatValue, or to nil for
+// ClearValues.
+func (f *BigFloatValue) Reset(policy ResetPolicy) {
+    *f.value = nil
+    if f.defaultValue != nil && policy != ClearValues { *f.value = new(big.Float).Set(f.defaultValue) }
+}
+
+func (f *BigFloatValue) Set(s string) error {
+    v, ok := new(big.Float).SetString(s)
+    if !ok {
+        return i18n.Errorf(i18n.InvalidNumber, s)
+    }
+    *f.value = v
+    return nil
+}
+
+func (f *BigFloatValue) Get() interface{} { return *f.value }
+
+func (f *BigFloatValue) String() string {
+    if *f.value == nil { return "" }
+    return (*f.value).Text('g', -1)
+}
+
+// Ratio is a fraction, which is parsed either as a percentage like "50%" or a number like "0.5".
+type Ratio float64
+
+// -- Ratio Value
+type PercentageValue Ratio
+
+func NewPercentageValue(val Ratio, p *Ratio) *PercentageValue {
+    *p = val
+    return (*PercentageValue)(p)
+}
+
+func (r *PercentageValue) Set(s string) error {
+    percent := strings.HasSuffix(s, "%")
+    v, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+    if err != nil { return err }
+    if percent { v /= 100 }
+    *r = PercentageValue(v)
+    return nil
+}
+
+func (r *PercentageValue) Get() interface{} { return Ratio(*r) }
+
+func (r *PercentageValue) String() string { return strconv.FormatFloat(float64(*r), 'g', -1, 64) }
+
+// -- time.Time Value, parsed using a layout as in time.Parse
+type TimeValue struct {
+    time *time.Time
+    layout string
+    defaultTime time.Time
+}
+
+func NewTimeValue(val time.Time, p *time.Time, layout string) *TimeValue {
+    *p = val
+    return &TimeValue{ p, layout, val }
+}
+
+// Reset sets the time back to the val passed to NewTimeValue, or to the zero time for ClearValues.
+func (t *TimeValue) Reset(policy ResetPolicy) {
+    *t.time = t.defaultTime
+    if policy == ClearValues { *t.time = time.Time{} }
+}
+
+func (t *TimeValue) Set(s string) error {
+    v, err := time.Parse(t.layout, s)
+    if err != nil { return err }
+    *t.time = v
+    return nil
+}
+
+func (t *TimeValue) Get() interface{} { return *t.time }
+
+func (t *TimeValue) String() string {
+    if t.time == nil { return "" }
+    return t.time.Format(t.layout)
+}
+
+// TimeValueList is a list of time.Time, parsed using a layout, that can receive a list of values
+type TimeValueList struct {
+    Values []time.Time
+    Layout string
+}
+
+// Set sets the list of strings onto the list
+func (list *TimeValueList) Set(strings []string) error {
+    for _, s := range strings {
+        v, err := time.Parse(list.Layout, s)
+        if err != nil { return err }
+        list.Values = append(list.Values, v)
+    }
+    return nil
+}
+
+// Time creates a parameter of type time.Time, parsed using the layout as in time.Parse.
+func (ps *ParamSet) Time(name string, layout string, optional bool, metadata interface{}) *time.Time {
+    var tmp time.Time
+    ps.VarValue(NewTimeValue(tmp, &tmp, layout), name, optional, metadata)
+    return &tmp
+}
+
+// Time creates a parameter of type time.Time, parsed using the layout as in time.Parse.
+func Time(name string, layout string, optional bool, metadata interface{}) *time.Time {
+    return defaultParamSet.Time(name, layout, optional, metadata)
+}
+
+// TimeList creates a parameter of type time.Time that captures all the remaining arguments.
+func (ps *ParamSet) TimeList(name string, layout string, optional bool, metadata interface{}) *[]time.Time {
+    minLength := 0
+    if !optional { minLength = 1 }
+    return ps.TimeListCustom(name, layout, minLength, -1, metadata)
+}
+
+// TimeList creates a parameter of type time.Time that captures all the remaining arguments.
+func TimeList(name string, layout string, optional bool, metadata interface{}) *[]time.Time {
+    return defaultParamSet.TimeList(name, layout, optional, metadata)
+}
+
+// TimeListCustom creates a parameter of type time.Time that captures a list of the specified min
+// and max length from the remaining arguments
+func (ps *ParamSet) TimeListCustom(name string, layout string, minLength int, maxLength int, metadata interface{}) *[]time.Time {
+    list := &TimeValueList{ Layout: layout }
+    ps.VarListCustom(list, name, minLength, maxLength, metadata)
+    return &list.Values
+}
+
+// TimeListCustom creates a parameter of type time.Time that captures a list of the specified min
+// and max length from the remaining arguments
+func TimeListCustom(name string, layout string, minLength int, maxLength int, metadata interface{}) *[]time.Time {
+    return defaultParamSet.TimeListCustom(name, layout, minLength, maxLength, metadata)
+}
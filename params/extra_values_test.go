@@ -0,0 +1,155 @@
+package params
+
+import (
+    "math/big"
+    "net"
+    "os"
+    "reflect"
+    "regexp"
+    "testing"
+    "time"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+func TestURLParsing(t *testing.T) {
+    p := new(ParamSet)
+    u := p.URL("url", false, nil)
+    err := p.Parse([]string { "https://example.com/path?q=1" })
+
+    if err != nil { t.Errorf("Error: %v", err) }
+    if u.Host != "example.com" || u.Path != "/path" { t.Errorf("Unexpected url: %v", u) }
+}
+
+func TestIPValueList(t *testing.T) {
+    il := new(IPValueList)
+    err := il.Set([]string { "127.0.0.1", "::1" })
+
+    if err != nil { t.Errorf("Error: %v", err) }
+    if !reflect.DeepEqual(*il, IPValueList([]net.IP { net.ParseIP("127.0.0.1"), net.ParseIP("::1") })) {
+        t.Errorf("Unexpected il: %v", il)
+    }
+    if err := il.Set([]string { "not-an-ip" }); err == nil { t.Errorf("Error should be thrown") }
+}
+
+func TestLocalizedExtraValueErrors(t *testing.T) {
+    i18n.SetLocale("de")
+    defer i18n.SetLocale("en")
+    errIP := new(IPValueList).Set([]string { "not-an-ip" })
+    errSize := new(SizeValueList).Set([]string { "10XB" })
+    errRange := new(SizeValueList).Set([]string { "99999999999EB" })
+
+    if errIP == nil || errIP.Error() != `Ungültige IP-Adresse "not-an-ip"` { t.Errorf("Error should be translated, but was %v", errIP) }
+    if errSize == nil || errSize.Error() != `Ungültige Bytegröße "10XB"` { t.Errorf("Error should be translated, but was %v", errSize) }
+    if errRange == nil || errRange.Error() != `Bytegröße "99999999999EB" außerhalb des gültigen Bereichs` {
+        t.Errorf("Error should be translated, but was %v", errRange)
+    }
+}
+
+func TestIPNetParsing(t *testing.T) {
+    p := new(ParamSet)
+    n := p.IPNet("net", false, nil)
+    err := p.Parse([]string { "10.1.2.3/8" })
+
+    if err != nil { t.Errorf("Error: %v", err) }
+    if n.String() != "10.0.0.0/8" { t.Errorf("Unexpected net: %v", n) }
+}
+
+func TestRegexpParsing(t *testing.T) {
+    p := new(ParamSet)
+    r := p.Regexp("pattern", false, nil)
+    err := p.Parse([]string { "^a+b$" })
+
+    if err != nil { t.Errorf("Error: %v", err) }
+    if !(*r).MatchString("aab") || (*r).MatchString("abc") { t.Errorf("Unexpected regexp: %v", *r) }
+    if err := p.Parse([]string { "(" }); err == nil { t.Errorf("Error should be thrown") }
+}
+
+func TestSizeValueList(t *testing.T) {
+    sl := new(SizeValueList)
+    err := sl.Set([]string { "10", "10MiB", "1.5K", "2kB", "3G" })
+
+    if err != nil { t.Errorf("Error: %v", err) }
+    if !reflect.DeepEqual(*sl, SizeValueList([]ByteSize { 10, 10 << 20, 1536, 2000, 3 << 30 })) {
+        t.Errorf("Unexpected sl: %v", sl)
+    }
+    if err := sl.Set([]string { "10XB" }); err == nil { t.Errorf("Error should be thrown") }
+    if s := ByteSize(10 << 20).String(); s != "10MiB" { t.Errorf("Unexpected string: %s", s) }
+}
+
+func TestFileModeValueList(t *testing.T) {
+    ml := new(FileModeValueList)
+    err := ml.Set([]string { "644", "0755", "1777" })
+
+    if err != nil { t.Errorf("Error: %v", err) }
+    if !reflect.DeepEqual(*ml, FileModeValueList([]os.FileMode { 0644, 0755, os.ModeSticky | 0777 })) {
+        t.Errorf("Unexpected ml: %v", ml)
+    }
+    if s := (*FileModeValue)(&(*ml)[2]).String(); s != "01777" { t.Errorf("Unexpected string: %s", s) }
+}
+
+func TestBigIntParsing(t *testing.T) {
+    p := new(ParamSet)
+    i := p.BigInt("number", false, nil)
+    err := p.Parse([]string { "123456789012345678901234567890" })
+
+    expected, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+    if err != nil { t.Errorf("Error: %v", err) }
+    if (*i).Cmp(expected) != 0 { t.Errorf("Unexpected number: %v", *i) }
+}
+
+func TestBigIntValueListDoesNotShare(t *testing.T) {
+    list := new(BigIntValueList)
+    err := list.Set([]string { "1", "2" })
+    if err != nil { t.Errorf("Error: %v", err) }
+
+    (*list)[0].Add((*list)[0], big.NewInt(10))
+
+    if (*list)[0].Int64() != 11 || (*list)[1].Int64() != 2 { t.Errorf("Unexpected list: %v", *list) }
+}
+
+func TestBigFloatParsing(t *testing.T) {
+    p := new(ParamSet)
+    f := p.BigFloat("number", false, nil)
+    err := p.Parse([]string { "1.5e100" })
+
+    if err != nil { t.Errorf("Error: %v", err) }
+    if (*f).Text('g', -1) != "1.5e+100" { t.Errorf("Unexpected number: %v", *f) }
+}
+
+func TestPercentageValueList(t *testing.T) {
+    pl := new(PercentageValueList)
+    err := pl.Set([]string { "50%", "0.25", "150%" })
+
+    if err != nil { t.Errorf("Error: %v", err) }
+    if !reflect.DeepEqual(*pl, PercentageValueList([]Ratio { 0.5, 0.25, 1.5 })) {
+        t.Errorf("Unexpected pl: %v", pl)
+    }
+}
+
+func TestTimeParsing(t *testing.T) {
+    p := new(ParamSet)
+    start := p.Time("start", "2006-01-02", false, nil)
+    rest := p.TimeList("rest", time.Kitchen, true, nil)
+    err := p.Parse([]string { "2017-10-01", "3:04PM" })
+
+    if err != nil { t.Errorf("Error: %v", err) }
+    if !start.Equal(time.Date(2017, 10, 1, 0, 0, 0, 0, time.UTC)) { t.Errorf("Unexpected start: %v", start) }
+    if len(*rest) != 1 || (*rest)[0].Hour() != 15 { t.Errorf("Unexpected rest: %v", *rest) }
+}
+
+func TestExtraValueGroups(t *testing.T) {
+    type route struct {
+        Net net.IPNet
+        Pattern *regexp.Regexp
+    }
+    p := new(ParamSet)
+    var routes []route
+    p.GroupList(&routes, "routes", false, nil)
+    err := p.Parse([]string { "10.0.0.0/8", "^a$" })
+
+    if err != nil { t.Errorf("Error: %v", err) }
+    if len(routes) != 1 || routes[0].Net.String() != "10.0.0.0/8" || !routes[0].Pattern.MatchString("a") {
+        t.Errorf("Unexpected routes: %v", routes)
+    }
+}
@@ -0,0 +1,247 @@
+package params
+
+import (
+    "io"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+// StdioPath is the path that refers to stdin for input files, and stdout for output files.
+const StdioPath = "-"
+
+// expandHome expands a leading "~" in the path to the home directory of the current user.
+func expandHome(path string) (string, error) {
+    if path != "~" && !strings.HasPrefix(path, "~" + string(filepath.Separator)) && !strings.HasPrefix(path, "~/") {
+        return path, nil
+    }
+    home, err := os.UserHomeDir()
+    if err != nil { return "", err }
+    return filepath.Join(home, path[1:]), nil
+}
+
+// InputFileValue is a Value for a file to be read. "-" refers to stdin, and a leading "~" is
+// expanded to the home directory. The file is validated to be a readable file when it is set. If
+// Lazy is false, the file is also opened when it is set, otherwise it is opened when Open is
+// called.
+type InputFileValue struct {
+    Path string
+    Lazy bool
+    file io.ReadCloser
+}
+
+func (f *InputFileValue) Set(s string) error {
+    path, err := expandHome(s)
+    if err != nil { return err }
+    f.Close()
+    if path == StdioPath {
+        f.Path = path
+        return nil
+    }
+    file, err := os.Open(path)
+    if err != nil { return err }
+    if info, err := file.Stat(); err != nil || info.IsDir() {
+        file.Close()
+        if err != nil { return err }
+        return validationErrorf(i18n.IsDirectory, path)
+    }
+    f.Path = path
+    if f.Lazy {
+        return file.Close()
+    }
+    f.file = file
+    return nil
+}
+
+// Open returns the reader of the file, opening it if necessary. Closing the reader of stdin does
+// not close os.Stdin.
+func (f *InputFileValue) Open() (io.ReadCloser, error) {
+    if f.file == nil {
+        if f.Path == StdioPath {
+            f.file = ioutil.NopCloser(os.Stdin)
+        } else {
+            file, err := os.Open(f.Path)
+            if err != nil { return nil, err }
+            f.file = file
+        }
+    }
+    return f.file, nil
+}
+
+// Close closes the file if it is open.
+func (f *InputFileValue) Close() error {
+    if f.file == nil { return nil }
+    err := f.file.Close()
+    f.file = nil
+    return err
+}
+
+func (f *InputFileValue) Get() interface{} { return f }
+
+func (f *InputFileValue) String() string { return f.Path }
+
+// InputFileValueList is a list of input files that can receive a list of values
+type InputFileValueList struct {
+    Files []*InputFileValue
+    Lazy bool
+}
+
+// Set sets the list of strings onto the list
+func (list *InputFileValueList) Set(strings []string) error {
+    for _, s := range strings {
+        f := &InputFileValue{ Lazy: list.Lazy }
+        if err := f.Set(s); err != nil { return err }
+        list.Files = append(list.Files, f)
+    }
+    return nil
+}
+
+// Close closes all the files in the list, returning the first error.
+func (list *InputFileValueList) Close() error {
+    var firstErr error
+    for _, f := range list.Files {
+        if err := f.Close(); err != nil && firstErr == nil {
+            firstErr = err
+        }
+    }
+    return firstErr
+}
+
+// OutputFileValue is a Value for a file to be written. "-" refers to stdout, and a leading "~" is
+// expanded to the home directory. Setting the value only validates the path. If Lazy is false, the
+// file is created or truncated after all the arguments were parsed successfully, see Committer,
+// otherwise it is created when Open is called.
+type OutputFileValue struct {
+    Path string
+    Lazy bool
+    file io.WriteCloser
+}
+
+type nopWriteCloser struct {
+    io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func (f *OutputFileValue) Set(s string) error {
+    path, err := expandHome(s)
+    if err != nil { return err }
+    f.Close()
+    if path == StdioPath {
+        f.Path = path
+        return nil
+    }
+    if info, err := os.Stat(path); err == nil && info.IsDir() {
+        return validationErrorf(i18n.IsDirectory, path)
+    }
+    if info, err := os.Stat(filepath.Dir(path)); err != nil || !info.IsDir() {
+        return validationErrorf(i18n.MissingDirectory, path)
+    }
+    f.Path = path
+    return nil
+}
+
+// Commit creates or truncates the file unless Lazy is true. Parse calls Commit after all the
+// arguments were set, so that an existing file is not truncated if another argument is invalid.
+func (f *OutputFileValue) Commit() error {
+    if f.Lazy { return nil }
+    _, err := f.Open()
+    return err
+}
+
+// Open returns the writer of the file, creating it if necessary. Closing the writer of stdout does
+// not close os.Stdout.
+func (f *OutputFileValue) Open() (io.WriteCloser, error) {
+    if f.file == nil {
+        if f.Path == StdioPath {
+            f.file = nopWriteCloser{ os.Stdout }
+        } else {
+            file, err := os.Create(f.Path)
+            if err != nil { return nil, err }
+            f.file = file
+        }
+    }
+    return f.file, nil
+}
+
+// Close closes the file if it is open.
+func (f *OutputFileValue) Close() error {
+    if f.file == nil { return nil }
+    err := f.file.Close()
+    f.file = nil
+    return err
+}
+
+func (f *OutputFileValue) Get() interface{} { return f }
+
+func (f *OutputFileValue) String() string { return f.Path }
+
+// DirValue is a Value for an existing directory. A leading "~" is expanded to the home directory.
+type DirValue string
+
+func (d *DirValue) Set(s string) error {
+    path, err := expandHome(s)
+    if err != nil { return err }
+    info, err := os.Stat(path)
+    if err != nil { return err }
+    if !info.IsDir() { return validationErrorf(i18n.NotDirectory, path) }
+    *d = DirValue(path)
+    return nil
+}
+
+func (d *DirValue) Get() interface{} { return string(*d) }
+
+func (d *DirValue) String() string { return string(*d) }
+
+// InputFile creates a parameter for a file to be read. See InputFileValue for details.
+func (ps *ParamSet) InputFile(name string, lazy bool, optional bool, metadata interface{}) *InputFileValue {
+    f := &InputFileValue{ Lazy: lazy }
+    ps.VarValue(f, name, optional, metadata)
+    return f
+}
+
+// InputFile creates a parameter for a file to be read. See InputFileValue for details.
+func InputFile(name string, lazy bool, optional bool, metadata interface{}) *InputFileValue {
+    return defaultParamSet.InputFile(name, lazy, optional, metadata)
+}
+
+// InputFileList creates a parameter for files to be read that captures all the remaining
+// arguments.
+func (ps *ParamSet) InputFileList(name string, lazy bool, optional bool, metadata interface{}) *[]*InputFileValue {
+    list := &InputFileValueList{ Lazy: lazy }
+    ps.VarList(list, name, optional, metadata)
+    return &list.Files
+}
+
+// InputFileList creates a parameter for files to be read that captures all the remaining
+// arguments.
+func InputFileList(name string, lazy bool, optional bool, metadata interface{}) *[]*InputFileValue {
+    return defaultParamSet.InputFileList(name, lazy, optional, metadata)
+}
+
+// OutputFile creates a parameter for a file to be written. See OutputFileValue for details.
+func (ps *ParamSet) OutputFile(name string, lazy bool, optional bool, metadata interface{}) *OutputFileValue {
+    f := &OutputFileValue{ Lazy: lazy }
+    ps.VarValue(f, name, optional, metadata)
+    return f
+}
+
+// OutputFile creates a parameter for a file to be written. See OutputFileValue for details.
+func OutputFile(name string, lazy bool, optional bool, metadata interface{}) *OutputFileValue {
+    return defaultParamSet.OutputFile(name, lazy, optional, metadata)
+}
+
+// Dir creates a parameter for an existing directory.
+func (ps *ParamSet) Dir(name string, optional bool, metadata interface{}) *string {
+    var tmp string
+    ps.VarValue((*DirValue)(&tmp), name, optional, metadata)
+    return &tmp
+}
+
+// Dir creates a parameter for an existing directory.
+func Dir(name string, optional bool, metadata interface{}) *string {
+    return defaultParamSet.Dir(name, optional, metadata)
+}
@@ -0,0 +1,150 @@
+package params
+
+import (
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+func tempDir(t *testing.T) string {
+    dir, err := ioutil.TempDir("", "gocmdln")
+    if err != nil { t.Fatalf("Cannot create temp dir: %v", err) }
+    return dir
+}
+
+func TestInputFileParsing(t *testing.T) {
+
+    // Test setup
+
+    dir := tempDir(t)
+    defer os.RemoveAll(dir)
+    path := filepath.Join(dir, "input.txt")
+    ioutil.WriteFile(path, []byte("hello"), 0644)
+
+    p := new(ParamSet)
+    input := p.InputFile("input", false, false, nil)
+    lazyInputs := p.InputFileList("lazyInputs", true, true, nil)
+
+    // Test execution
+
+    err := p.Parse([]string { path, path, "-" })
+
+    // Assertions
+
+    if err != nil { t.Fatalf("Error is not nil: %v", err) }
+    defer input.Close()
+    if input.file == nil { t.Errorf("input should be opened") }
+    reader, _ := input.Open()
+    if content, _ := ioutil.ReadAll(reader); string(content) != "hello" {
+        t.Errorf(`content should be "hello", but was %s`, content)
+    }
+    if len(*lazyInputs) != 2 || (*lazyInputs)[0].file != nil || (*lazyInputs)[1].Path != StdioPath {
+        t.Errorf("Unexpected lazyInputs: %v", *lazyInputs)
+    }
+}
+
+func TestInputFileErrors(t *testing.T) {
+    dir := tempDir(t)
+    defer os.RemoveAll(dir)
+
+    t.Run("missing file", func (t *testing.T) {
+        f := new(InputFileValue)
+        if err := f.Set(filepath.Join(dir, "missing")); err == nil { t.Errorf("Error should be thrown") }
+    })
+
+    t.Run("directory", func (t *testing.T) {
+        f := new(InputFileValue)
+        if err := f.Set(dir); err == nil { t.Errorf("Error should be thrown") }
+    })
+}
+
+func TestFilesClosedWhenParsingFails(t *testing.T) {
+    // Setup
+    dir := tempDir(t)
+    defer os.RemoveAll(dir)
+    path := filepath.Join(dir, "input.txt")
+    ioutil.WriteFile(path, []byte("hello"), 0644)
+
+    p := new(ParamSet)
+    input := p.InputFile("input", false, false, nil)
+    p.Int("count", false, nil)
+
+    // Execution
+    err := p.Parse([]string { path, "abc" })
+
+    // Assertions
+    if err == nil { t.Errorf("Error should be thrown") }
+    if input.file != nil { t.Errorf("input should be closed") }
+}
+
+func TestOutputFile(t *testing.T) {
+    // Setup
+    dir := tempDir(t)
+    defer os.RemoveAll(dir)
+    path := filepath.Join(dir, "output.txt")
+
+    p := new(ParamSet)
+    output := p.OutputFile("output", true, false, nil)
+    outDir := p.Dir("dir", false, nil)
+
+    // Execution
+    err := p.Parse([]string { path, dir })
+
+    // Assertions
+    if err != nil { t.Fatalf("Unexpected error: %v", err) }
+    if _, err := os.Stat(path); !os.IsNotExist(err) { t.Errorf("Lazy output should not be created yet") }
+    writer, err := output.Open()
+    if err != nil { t.Fatalf("Unexpected error: %v", err) }
+    writer.Write([]byte("world"))
+    output.Close()
+    if content, _ := ioutil.ReadFile(path); string(content) != "world" {
+        t.Errorf(`content should be "world", but was %s`, content)
+    }
+    if *outDir != dir { t.Errorf("Unexpected dir: %s", *outDir) }
+    if err := output.Set(filepath.Join(dir, "missing", "output.txt")); err == nil {
+        t.Errorf("Error should be thrown for missing directory")
+    }
+}
+
+func TestOutputFileCreatedAfterParse(t *testing.T) {
+    // Setup
+    dir := tempDir(t)
+    defer os.RemoveAll(dir)
+    path := filepath.Join(dir, "output.txt")
+    ioutil.WriteFile(path, []byte("hello"), 0600)
+
+    p := new(ParamSet)
+    output := p.OutputFile("output", false, false, nil)
+    p.Int("count", false, nil)
+
+    // Execution
+    err := p.Parse([]string { path, "NaN" })
+
+    // Assertions
+    if err == nil { t.Errorf("Error should be thrown") }
+    if content, _ := ioutil.ReadFile(path); string(content) != "hello" {
+        t.Errorf("Existing file should not be truncated, but was %q", content)
+    }
+
+    // Execution
+    err = p.Parse([]string { path, "1" })
+
+    // Assertions
+    if err != nil { t.Fatalf("Unexpected error: %v", err) }
+    if output.file == nil { t.Errorf("Output should be opened after the parse") }
+    if content, _ := ioutil.ReadFile(path); string(content) != "" {
+        t.Errorf("Output should be truncated after the parse, but was %q", content)
+    }
+    output.Close()
+}
+
+func TestExpandHome(t *testing.T) {
+    home, err := os.UserHomeDir()
+    if err != nil { t.Skip("No home directory") }
+
+    if path, _ := expandHome("~/file"); path != filepath.Join(home, "file") {
+        t.Errorf("Unexpected path: %s", path)
+    }
+    if path, _ := expandHome("a/~/file"); path != "a/~/file" { t.Errorf("Unexpected path: %s", path) }
+}
@@ -5,6 +5,12 @@
 package params
 
 import (
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
 	"time"
 )
 
@@ -14,16 +20,30 @@ type BoolValueList []bool
 // Set sets the list of strings onto the string
 func (list *BoolValueList) Set(strings []string) error {
 	for _, s := range strings {
-		iv := new(BoolValue)
-		err := iv.Set(s)
+		var v bool
+		err := NewBoolValue(v, &v).Set(s)
 		if err != nil {
 			return err
 		}
-		*list = append(*list, bool(*iv))
+		*list = append(*list, v)
 	}
 	return nil
 }
 
+// Get returns the values of the list as a []bool
+func (list *BoolValueList) Get() interface{} {
+	return []bool(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *BoolValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewBoolValue((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
 // Bool creates a parameter of type bool.
 func (ps *ParamSet) Bool(name string, optional bool, metadata interface{}) *bool {
 	var tmp bool
@@ -65,22 +85,107 @@ func BoolListCustom(name string, minLength int, maxLength int, metadata interfac
 	return defaultParamSet.BoolListCustom(name, minLength, maxLength, metadata)
 }
 
+// BoolValueMap is a map of bool that can receive a list of KEY=VALUE values
+type BoolValueMap struct {
+	Values map[string]bool
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *BoolValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v bool
+		err = NewBoolValue(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]bool)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]bool
+func (m *BoolValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *BoolValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewBoolValue(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// BoolMap creates a parameter of type bool that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) BoolMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]bool {
+	m := &BoolValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// BoolMap creates a parameter of type bool that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func BoolMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]bool {
+	return defaultParamSet.BoolMap(name, policy, optional, metadata)
+}
+
 // StringValueList is a list of string that can receive a list of values
 type StringValueList []string
 
 // Set sets the list of strings onto the string
 func (list *StringValueList) Set(strings []string) error {
 	for _, s := range strings {
-		iv := new(StringValue)
-		err := iv.Set(s)
+		var v string
+		err := NewStringValue(v, &v).Set(s)
 		if err != nil {
 			return err
 		}
-		*list = append(*list, string(*iv))
+		*list = append(*list, v)
 	}
 	return nil
 }
 
+// Get returns the values of the list as a []string
+func (list *StringValueList) Get() interface{} {
+	return []string(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *StringValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewStringValue((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
 // String creates a parameter of type string.
 func (ps *ParamSet) String(name string, optional bool, metadata interface{}) *string {
 	var tmp string
@@ -122,22 +227,107 @@ func StringListCustom(name string, minLength int, maxLength int, metadata interf
 	return defaultParamSet.StringListCustom(name, minLength, maxLength, metadata)
 }
 
+// StringValueMap is a map of string that can receive a list of KEY=VALUE values
+type StringValueMap struct {
+	Values map[string]string
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *StringValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v string
+		err = NewStringValue(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]string)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]string
+func (m *StringValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *StringValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewStringValue(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// StringMap creates a parameter of type string that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) StringMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]string {
+	m := &StringValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// StringMap creates a parameter of type string that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func StringMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]string {
+	return defaultParamSet.StringMap(name, policy, optional, metadata)
+}
+
 // IntValueList is a list of int that can receive a list of values
 type IntValueList []int
 
 // Set sets the list of strings onto the string
 func (list *IntValueList) Set(strings []string) error {
 	for _, s := range strings {
-		iv := new(IntValue)
-		err := iv.Set(s)
+		var v int
+		err := NewIntValue(v, &v).Set(s)
 		if err != nil {
 			return err
 		}
-		*list = append(*list, int(*iv))
+		*list = append(*list, v)
 	}
 	return nil
 }
 
+// Get returns the values of the list as a []int
+func (list *IntValueList) Get() interface{} {
+	return []int(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *IntValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewIntValue((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
 // Int creates a parameter of type int.
 func (ps *ParamSet) Int(name string, optional bool, metadata interface{}) *int {
 	var tmp int
@@ -179,22 +369,107 @@ func IntListCustom(name string, minLength int, maxLength int, metadata interface
 	return defaultParamSet.IntListCustom(name, minLength, maxLength, metadata)
 }
 
+// IntValueMap is a map of int that can receive a list of KEY=VALUE values
+type IntValueMap struct {
+	Values map[string]int
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *IntValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v int
+		err = NewIntValue(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]int)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]int
+func (m *IntValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *IntValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewIntValue(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// IntMap creates a parameter of type int that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) IntMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]int {
+	m := &IntValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// IntMap creates a parameter of type int that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func IntMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]int {
+	return defaultParamSet.IntMap(name, policy, optional, metadata)
+}
+
 // Int64ValueList is a list of int64 that can receive a list of values
 type Int64ValueList []int64
 
 // Set sets the list of strings onto the string
 func (list *Int64ValueList) Set(strings []string) error {
 	for _, s := range strings {
-		iv := new(Int64Value)
-		err := iv.Set(s)
+		var v int64
+		err := NewInt64Value(v, &v).Set(s)
 		if err != nil {
 			return err
 		}
-		*list = append(*list, int64(*iv))
+		*list = append(*list, v)
 	}
 	return nil
 }
 
+// Get returns the values of the list as a []int64
+func (list *Int64ValueList) Get() interface{} {
+	return []int64(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *Int64ValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewInt64Value((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
 // Int64 creates a parameter of type int64.
 func (ps *ParamSet) Int64(name string, optional bool, metadata interface{}) *int64 {
 	var tmp int64
@@ -236,22 +511,107 @@ func Int64ListCustom(name string, minLength int, maxLength int, metadata interfa
 	return defaultParamSet.Int64ListCustom(name, minLength, maxLength, metadata)
 }
 
+// Int64ValueMap is a map of int64 that can receive a list of KEY=VALUE values
+type Int64ValueMap struct {
+	Values map[string]int64
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *Int64ValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v int64
+		err = NewInt64Value(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]int64)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]int64
+func (m *Int64ValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *Int64ValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewInt64Value(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// Int64Map creates a parameter of type int64 that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) Int64Map(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]int64 {
+	m := &Int64ValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// Int64Map creates a parameter of type int64 that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func Int64Map(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]int64 {
+	return defaultParamSet.Int64Map(name, policy, optional, metadata)
+}
+
 // UintValueList is a list of uint that can receive a list of values
 type UintValueList []uint
 
 // Set sets the list of strings onto the string
 func (list *UintValueList) Set(strings []string) error {
 	for _, s := range strings {
-		iv := new(UintValue)
-		err := iv.Set(s)
+		var v uint
+		err := NewUintValue(v, &v).Set(s)
 		if err != nil {
 			return err
 		}
-		*list = append(*list, uint(*iv))
+		*list = append(*list, v)
 	}
 	return nil
 }
 
+// Get returns the values of the list as a []uint
+func (list *UintValueList) Get() interface{} {
+	return []uint(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *UintValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewUintValue((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
 // Uint creates a parameter of type uint.
 func (ps *ParamSet) Uint(name string, optional bool, metadata interface{}) *uint {
 	var tmp uint
@@ -293,22 +653,107 @@ func UintListCustom(name string, minLength int, maxLength int, metadata interfac
 	return defaultParamSet.UintListCustom(name, minLength, maxLength, metadata)
 }
 
+// UintValueMap is a map of uint that can receive a list of KEY=VALUE values
+type UintValueMap struct {
+	Values map[string]uint
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *UintValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v uint
+		err = NewUintValue(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]uint)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]uint
+func (m *UintValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *UintValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewUintValue(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// UintMap creates a parameter of type uint that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) UintMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]uint {
+	m := &UintValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// UintMap creates a parameter of type uint that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func UintMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]uint {
+	return defaultParamSet.UintMap(name, policy, optional, metadata)
+}
+
 // Uint64ValueList is a list of uint64 that can receive a list of values
 type Uint64ValueList []uint64
 
 // Set sets the list of strings onto the string
 func (list *Uint64ValueList) Set(strings []string) error {
 	for _, s := range strings {
-		iv := new(Uint64Value)
-		err := iv.Set(s)
+		var v uint64
+		err := NewUint64Value(v, &v).Set(s)
 		if err != nil {
 			return err
 		}
-		*list = append(*list, uint64(*iv))
+		*list = append(*list, v)
 	}
 	return nil
 }
 
+// Get returns the values of the list as a []uint64
+func (list *Uint64ValueList) Get() interface{} {
+	return []uint64(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *Uint64ValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewUint64Value((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
 // Uint64 creates a parameter of type uint64.
 func (ps *ParamSet) Uint64(name string, optional bool, metadata interface{}) *uint64 {
 	var tmp uint64
@@ -350,22 +795,107 @@ func Uint64ListCustom(name string, minLength int, maxLength int, metadata interf
 	return defaultParamSet.Uint64ListCustom(name, minLength, maxLength, metadata)
 }
 
+// Uint64ValueMap is a map of uint64 that can receive a list of KEY=VALUE values
+type Uint64ValueMap struct {
+	Values map[string]uint64
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *Uint64ValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v uint64
+		err = NewUint64Value(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]uint64)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]uint64
+func (m *Uint64ValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *Uint64ValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewUint64Value(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// Uint64Map creates a parameter of type uint64 that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) Uint64Map(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]uint64 {
+	m := &Uint64ValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// Uint64Map creates a parameter of type uint64 that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func Uint64Map(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]uint64 {
+	return defaultParamSet.Uint64Map(name, policy, optional, metadata)
+}
+
 // Float64ValueList is a list of float64 that can receive a list of values
 type Float64ValueList []float64
 
 // Set sets the list of strings onto the string
 func (list *Float64ValueList) Set(strings []string) error {
 	for _, s := range strings {
-		iv := new(Float64Value)
-		err := iv.Set(s)
+		var v float64
+		err := NewFloat64Value(v, &v).Set(s)
 		if err != nil {
 			return err
 		}
-		*list = append(*list, float64(*iv))
+		*list = append(*list, v)
 	}
 	return nil
 }
 
+// Get returns the values of the list as a []float64
+func (list *Float64ValueList) Get() interface{} {
+	return []float64(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *Float64ValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewFloat64Value((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
 // Float64 creates a parameter of type float64.
 func (ps *ParamSet) Float64(name string, optional bool, metadata interface{}) *float64 {
 	var tmp float64
@@ -407,22 +937,107 @@ func Float64ListCustom(name string, minLength int, maxLength int, metadata inter
 	return defaultParamSet.Float64ListCustom(name, minLength, maxLength, metadata)
 }
 
+// Float64ValueMap is a map of float64 that can receive a list of KEY=VALUE values
+type Float64ValueMap struct {
+	Values map[string]float64
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *Float64ValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v float64
+		err = NewFloat64Value(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]float64)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]float64
+func (m *Float64ValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *Float64ValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewFloat64Value(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// Float64Map creates a parameter of type float64 that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) Float64Map(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]float64 {
+	m := &Float64ValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// Float64Map creates a parameter of type float64 that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func Float64Map(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]float64 {
+	return defaultParamSet.Float64Map(name, policy, optional, metadata)
+}
+
 // DurationValueList is a list of duration that can receive a list of values
 type DurationValueList []time.Duration
 
 // Set sets the list of strings onto the string
 func (list *DurationValueList) Set(strings []string) error {
 	for _, s := range strings {
-		iv := new(DurationValue)
-		err := iv.Set(s)
+		var v time.Duration
+		err := NewDurationValue(v, &v).Set(s)
 		if err != nil {
 			return err
 		}
-		*list = append(*list, time.Duration(*iv))
+		*list = append(*list, v)
 	}
 	return nil
 }
 
+// Get returns the values of the list as a []duration
+func (list *DurationValueList) Get() interface{} {
+	return []time.Duration(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *DurationValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewDurationValue((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
 // Duration creates a parameter of type duration.
 func (ps *ParamSet) Duration(name string, optional bool, metadata interface{}) *time.Duration {
 	var tmp time.Duration
@@ -463,3 +1078,1352 @@ func (ps *ParamSet) DurationListCustom(name string, minLength int, maxLength int
 func DurationListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]time.Duration {
 	return defaultParamSet.DurationListCustom(name, minLength, maxLength, metadata)
 }
+
+// DurationValueMap is a map of duration that can receive a list of KEY=VALUE values
+type DurationValueMap struct {
+	Values map[string]time.Duration
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *DurationValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v time.Duration
+		err = NewDurationValue(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]time.Duration)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]duration
+func (m *DurationValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *DurationValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewDurationValue(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// DurationMap creates a parameter of type duration that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) DurationMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]time.Duration {
+	m := &DurationValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// DurationMap creates a parameter of type duration that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func DurationMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]time.Duration {
+	return defaultParamSet.DurationMap(name, policy, optional, metadata)
+}
+
+// URLValueList is a list of url that can receive a list of values
+type URLValueList []url.URL
+
+// Set sets the list of strings onto the string
+func (list *URLValueList) Set(strings []string) error {
+	for _, s := range strings {
+		var v url.URL
+		err := NewURLValue(v, &v).Set(s)
+		if err != nil {
+			return err
+		}
+		*list = append(*list, v)
+	}
+	return nil
+}
+
+// Get returns the values of the list as a []url
+func (list *URLValueList) Get() interface{} {
+	return []url.URL(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *URLValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewURLValue((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
+// URL creates a parameter of type url.
+func (ps *ParamSet) URL(name string, optional bool, metadata interface{}) *url.URL {
+	var tmp url.URL
+	sv := NewURLValue(tmp, &tmp)
+	ps.VarValue(sv, name, optional, metadata)
+	return &tmp
+}
+
+// URL creates a parameter of type url.
+func URL(name string, optional bool, metadata interface{}) *url.URL {
+	return defaultParamSet.URL(name, optional, metadata)
+}
+
+// URLList creates a parameter of type url that captures all the remaining arguments.
+func (ps *ParamSet) URLList(name string, optional bool, metadata interface{}) *[]url.URL {
+	minLength := 0
+	if !optional {
+		minLength = 1
+	}
+	return ps.URLListCustom(name, minLength, -1, metadata)
+}
+
+// URLList creates a parameter of type url that captures all the remaining arguments.
+func URLList(name string, optional bool, metadata interface{}) *[]url.URL {
+	return defaultParamSet.URLList(name, optional, metadata)
+}
+
+// URLListCustom creates a parameter of type url that captures a list of the specified min and
+// max length from the remaining arguments
+func (ps *ParamSet) URLListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]url.URL {
+	list := new(URLValueList)
+	ps.VarListCustom(list, name, minLength, maxLength, metadata)
+	return (*[]url.URL)(list)
+}
+
+// URLListCustom creates a parameter of type url that captures a list of the specified min and
+// max length from the remaining arguments
+func URLListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]url.URL {
+	return defaultParamSet.URLListCustom(name, minLength, maxLength, metadata)
+}
+
+// URLValueMap is a map of url that can receive a list of KEY=VALUE values
+type URLValueMap struct {
+	Values map[string]url.URL
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *URLValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v url.URL
+		err = NewURLValue(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]url.URL)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]url
+func (m *URLValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *URLValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewURLValue(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// URLMap creates a parameter of type url that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) URLMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]url.URL {
+	m := &URLValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// URLMap creates a parameter of type url that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func URLMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]url.URL {
+	return defaultParamSet.URLMap(name, policy, optional, metadata)
+}
+
+// IPValueList is a list of ip that can receive a list of values
+type IPValueList []net.IP
+
+// Set sets the list of strings onto the string
+func (list *IPValueList) Set(strings []string) error {
+	for _, s := range strings {
+		var v net.IP
+		err := NewIPValue(v, &v).Set(s)
+		if err != nil {
+			return err
+		}
+		*list = append(*list, v)
+	}
+	return nil
+}
+
+// Get returns the values of the list as a []ip
+func (list *IPValueList) Get() interface{} {
+	return []net.IP(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *IPValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewIPValue((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
+// IP creates a parameter of type ip.
+func (ps *ParamSet) IP(name string, optional bool, metadata interface{}) *net.IP {
+	var tmp net.IP
+	sv := NewIPValue(tmp, &tmp)
+	ps.VarValue(sv, name, optional, metadata)
+	return &tmp
+}
+
+// IP creates a parameter of type ip.
+func IP(name string, optional bool, metadata interface{}) *net.IP {
+	return defaultParamSet.IP(name, optional, metadata)
+}
+
+// IPList creates a parameter of type ip that captures all the remaining arguments.
+func (ps *ParamSet) IPList(name string, optional bool, metadata interface{}) *[]net.IP {
+	minLength := 0
+	if !optional {
+		minLength = 1
+	}
+	return ps.IPListCustom(name, minLength, -1, metadata)
+}
+
+// IPList creates a parameter of type ip that captures all the remaining arguments.
+func IPList(name string, optional bool, metadata interface{}) *[]net.IP {
+	return defaultParamSet.IPList(name, optional, metadata)
+}
+
+// IPListCustom creates a parameter of type ip that captures a list of the specified min and
+// max length from the remaining arguments
+func (ps *ParamSet) IPListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]net.IP {
+	list := new(IPValueList)
+	ps.VarListCustom(list, name, minLength, maxLength, metadata)
+	return (*[]net.IP)(list)
+}
+
+// IPListCustom creates a parameter of type ip that captures a list of the specified min and
+// max length from the remaining arguments
+func IPListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]net.IP {
+	return defaultParamSet.IPListCustom(name, minLength, maxLength, metadata)
+}
+
+// IPValueMap is a map of ip that can receive a list of KEY=VALUE values
+type IPValueMap struct {
+	Values map[string]net.IP
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *IPValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v net.IP
+		err = NewIPValue(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]net.IP)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]ip
+func (m *IPValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *IPValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewIPValue(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// IPMap creates a parameter of type ip that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) IPMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]net.IP {
+	m := &IPValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// IPMap creates a parameter of type ip that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func IPMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]net.IP {
+	return defaultParamSet.IPMap(name, policy, optional, metadata)
+}
+
+// IPNetValueList is a list of ipnet that can receive a list of values
+type IPNetValueList []net.IPNet
+
+// Set sets the list of strings onto the string
+func (list *IPNetValueList) Set(strings []string) error {
+	for _, s := range strings {
+		var v net.IPNet
+		err := NewIPNetValue(v, &v).Set(s)
+		if err != nil {
+			return err
+		}
+		*list = append(*list, v)
+	}
+	return nil
+}
+
+// Get returns the values of the list as a []ipnet
+func (list *IPNetValueList) Get() interface{} {
+	return []net.IPNet(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *IPNetValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewIPNetValue((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
+// IPNet creates a parameter of type ipnet.
+func (ps *ParamSet) IPNet(name string, optional bool, metadata interface{}) *net.IPNet {
+	var tmp net.IPNet
+	sv := NewIPNetValue(tmp, &tmp)
+	ps.VarValue(sv, name, optional, metadata)
+	return &tmp
+}
+
+// IPNet creates a parameter of type ipnet.
+func IPNet(name string, optional bool, metadata interface{}) *net.IPNet {
+	return defaultParamSet.IPNet(name, optional, metadata)
+}
+
+// IPNetList creates a parameter of type ipnet that captures all the remaining arguments.
+func (ps *ParamSet) IPNetList(name string, optional bool, metadata interface{}) *[]net.IPNet {
+	minLength := 0
+	if !optional {
+		minLength = 1
+	}
+	return ps.IPNetListCustom(name, minLength, -1, metadata)
+}
+
+// IPNetList creates a parameter of type ipnet that captures all the remaining arguments.
+func IPNetList(name string, optional bool, metadata interface{}) *[]net.IPNet {
+	return defaultParamSet.IPNetList(name, optional, metadata)
+}
+
+// IPNetListCustom creates a parameter of type ipnet that captures a list of the specified min and
+// max length from the remaining arguments
+func (ps *ParamSet) IPNetListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]net.IPNet {
+	list := new(IPNetValueList)
+	ps.VarListCustom(list, name, minLength, maxLength, metadata)
+	return (*[]net.IPNet)(list)
+}
+
+// IPNetListCustom creates a parameter of type ipnet that captures a list of the specified min and
+// max length from the remaining arguments
+func IPNetListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]net.IPNet {
+	return defaultParamSet.IPNetListCustom(name, minLength, maxLength, metadata)
+}
+
+// IPNetValueMap is a map of ipnet that can receive a list of KEY=VALUE values
+type IPNetValueMap struct {
+	Values map[string]net.IPNet
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *IPNetValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v net.IPNet
+		err = NewIPNetValue(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]net.IPNet)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]ipnet
+func (m *IPNetValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *IPNetValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewIPNetValue(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// IPNetMap creates a parameter of type ipnet that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) IPNetMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]net.IPNet {
+	m := &IPNetValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// IPNetMap creates a parameter of type ipnet that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func IPNetMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]net.IPNet {
+	return defaultParamSet.IPNetMap(name, policy, optional, metadata)
+}
+
+// RegexpValueList is a list of regexp that can receive a list of values
+type RegexpValueList []*regexp.Regexp
+
+// Set sets the list of strings onto the string
+func (list *RegexpValueList) Set(strings []string) error {
+	for _, s := range strings {
+		var v *regexp.Regexp
+		err := NewRegexpValue(v, &v).Set(s)
+		if err != nil {
+			return err
+		}
+		*list = append(*list, v)
+	}
+	return nil
+}
+
+// Get returns the values of the list as a []regexp
+func (list *RegexpValueList) Get() interface{} {
+	return []*regexp.Regexp(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *RegexpValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewRegexpValue((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
+// Regexp creates a parameter of type regexp.
+func (ps *ParamSet) Regexp(name string, optional bool, metadata interface{}) **regexp.Regexp {
+	var tmp *regexp.Regexp
+	sv := NewRegexpValue(tmp, &tmp)
+	ps.VarValue(sv, name, optional, metadata)
+	return &tmp
+}
+
+// Regexp creates a parameter of type regexp.
+func Regexp(name string, optional bool, metadata interface{}) **regexp.Regexp {
+	return defaultParamSet.Regexp(name, optional, metadata)
+}
+
+// RegexpList creates a parameter of type regexp that captures all the remaining arguments.
+func (ps *ParamSet) RegexpList(name string, optional bool, metadata interface{}) *[]*regexp.Regexp {
+	minLength := 0
+	if !optional {
+		minLength = 1
+	}
+	return ps.RegexpListCustom(name, minLength, -1, metadata)
+}
+
+// RegexpList creates a parameter of type regexp that captures all the remaining arguments.
+func RegexpList(name string, optional bool, metadata interface{}) *[]*regexp.Regexp {
+	return defaultParamSet.RegexpList(name, optional, metadata)
+}
+
+// RegexpListCustom creates a parameter of type regexp that captures a list of the specified min and
+// max length from the remaining arguments
+func (ps *ParamSet) RegexpListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]*regexp.Regexp {
+	list := new(RegexpValueList)
+	ps.VarListCustom(list, name, minLength, maxLength, metadata)
+	return (*[]*regexp.Regexp)(list)
+}
+
+// RegexpListCustom creates a parameter of type regexp that captures a list of the specified min and
+// max length from the remaining arguments
+func RegexpListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]*regexp.Regexp {
+	return defaultParamSet.RegexpListCustom(name, minLength, maxLength, metadata)
+}
+
+// RegexpValueMap is a map of regexp that can receive a list of KEY=VALUE values
+type RegexpValueMap struct {
+	Values map[string]*regexp.Regexp
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *RegexpValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v *regexp.Regexp
+		err = NewRegexpValue(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]*regexp.Regexp)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]regexp
+func (m *RegexpValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *RegexpValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewRegexpValue(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// RegexpMap creates a parameter of type regexp that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) RegexpMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]*regexp.Regexp {
+	m := &RegexpValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// RegexpMap creates a parameter of type regexp that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func RegexpMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]*regexp.Regexp {
+	return defaultParamSet.RegexpMap(name, policy, optional, metadata)
+}
+
+// SizeValueList is a list of size that can receive a list of values
+type SizeValueList []ByteSize
+
+// Set sets the list of strings onto the string
+func (list *SizeValueList) Set(strings []string) error {
+	for _, s := range strings {
+		var v ByteSize
+		err := NewSizeValue(v, &v).Set(s)
+		if err != nil {
+			return err
+		}
+		*list = append(*list, v)
+	}
+	return nil
+}
+
+// Get returns the values of the list as a []size
+func (list *SizeValueList) Get() interface{} {
+	return []ByteSize(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *SizeValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewSizeValue((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
+// Size creates a parameter of type size.
+func (ps *ParamSet) Size(name string, optional bool, metadata interface{}) *ByteSize {
+	var tmp ByteSize
+	sv := NewSizeValue(tmp, &tmp)
+	ps.VarValue(sv, name, optional, metadata)
+	return &tmp
+}
+
+// Size creates a parameter of type size.
+func Size(name string, optional bool, metadata interface{}) *ByteSize {
+	return defaultParamSet.Size(name, optional, metadata)
+}
+
+// SizeList creates a parameter of type size that captures all the remaining arguments.
+func (ps *ParamSet) SizeList(name string, optional bool, metadata interface{}) *[]ByteSize {
+	minLength := 0
+	if !optional {
+		minLength = 1
+	}
+	return ps.SizeListCustom(name, minLength, -1, metadata)
+}
+
+// SizeList creates a parameter of type size that captures all the remaining arguments.
+func SizeList(name string, optional bool, metadata interface{}) *[]ByteSize {
+	return defaultParamSet.SizeList(name, optional, metadata)
+}
+
+// SizeListCustom creates a parameter of type size that captures a list of the specified min and
+// max length from the remaining arguments
+func (ps *ParamSet) SizeListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]ByteSize {
+	list := new(SizeValueList)
+	ps.VarListCustom(list, name, minLength, maxLength, metadata)
+	return (*[]ByteSize)(list)
+}
+
+// SizeListCustom creates a parameter of type size that captures a list of the specified min and
+// max length from the remaining arguments
+func SizeListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]ByteSize {
+	return defaultParamSet.SizeListCustom(name, minLength, maxLength, metadata)
+}
+
+// SizeValueMap is a map of size that can receive a list of KEY=VALUE values
+type SizeValueMap struct {
+	Values map[string]ByteSize
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *SizeValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v ByteSize
+		err = NewSizeValue(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]ByteSize)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]size
+func (m *SizeValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *SizeValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewSizeValue(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// SizeMap creates a parameter of type size that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) SizeMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]ByteSize {
+	m := &SizeValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// SizeMap creates a parameter of type size that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func SizeMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]ByteSize {
+	return defaultParamSet.SizeMap(name, policy, optional, metadata)
+}
+
+// FileModeValueList is a list of filemode that can receive a list of values
+type FileModeValueList []os.FileMode
+
+// Set sets the list of strings onto the string
+func (list *FileModeValueList) Set(strings []string) error {
+	for _, s := range strings {
+		var v os.FileMode
+		err := NewFileModeValue(v, &v).Set(s)
+		if err != nil {
+			return err
+		}
+		*list = append(*list, v)
+	}
+	return nil
+}
+
+// Get returns the values of the list as a []filemode
+func (list *FileModeValueList) Get() interface{} {
+	return []os.FileMode(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *FileModeValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewFileModeValue((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
+// FileMode creates a parameter of type filemode.
+func (ps *ParamSet) FileMode(name string, optional bool, metadata interface{}) *os.FileMode {
+	var tmp os.FileMode
+	sv := NewFileModeValue(tmp, &tmp)
+	ps.VarValue(sv, name, optional, metadata)
+	return &tmp
+}
+
+// FileMode creates a parameter of type filemode.
+func FileMode(name string, optional bool, metadata interface{}) *os.FileMode {
+	return defaultParamSet.FileMode(name, optional, metadata)
+}
+
+// FileModeList creates a parameter of type filemode that captures all the remaining arguments.
+func (ps *ParamSet) FileModeList(name string, optional bool, metadata interface{}) *[]os.FileMode {
+	minLength := 0
+	if !optional {
+		minLength = 1
+	}
+	return ps.FileModeListCustom(name, minLength, -1, metadata)
+}
+
+// FileModeList creates a parameter of type filemode that captures all the remaining arguments.
+func FileModeList(name string, optional bool, metadata interface{}) *[]os.FileMode {
+	return defaultParamSet.FileModeList(name, optional, metadata)
+}
+
+// FileModeListCustom creates a parameter of type filemode that captures a list of the specified min and
+// max length from the remaining arguments
+func (ps *ParamSet) FileModeListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]os.FileMode {
+	list := new(FileModeValueList)
+	ps.VarListCustom(list, name, minLength, maxLength, metadata)
+	return (*[]os.FileMode)(list)
+}
+
+// FileModeListCustom creates a parameter of type filemode that captures a list of the specified min and
+// max length from the remaining arguments
+func FileModeListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]os.FileMode {
+	return defaultParamSet.FileModeListCustom(name, minLength, maxLength, metadata)
+}
+
+// FileModeValueMap is a map of filemode that can receive a list of KEY=VALUE values
+type FileModeValueMap struct {
+	Values map[string]os.FileMode
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *FileModeValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v os.FileMode
+		err = NewFileModeValue(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]os.FileMode)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]filemode
+func (m *FileModeValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *FileModeValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewFileModeValue(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// FileModeMap creates a parameter of type filemode that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) FileModeMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]os.FileMode {
+	m := &FileModeValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// FileModeMap creates a parameter of type filemode that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func FileModeMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]os.FileMode {
+	return defaultParamSet.FileModeMap(name, policy, optional, metadata)
+}
+
+// BigIntValueList is a list of bigint that can receive a list of values
+type BigIntValueList []*big.Int
+
+// Set sets the list of strings onto the string
+func (list *BigIntValueList) Set(strings []string) error {
+	for _, s := range strings {
+		var v *big.Int
+		err := NewBigIntValue(v, &v).Set(s)
+		if err != nil {
+			return err
+		}
+		*list = append(*list, v)
+	}
+	return nil
+}
+
+// Get returns the values of the list as a []bigint
+func (list *BigIntValueList) Get() interface{} {
+	return []*big.Int(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *BigIntValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewBigIntValue((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
+// BigInt creates a parameter of type bigint.
+func (ps *ParamSet) BigInt(name string, optional bool, metadata interface{}) **big.Int {
+	var tmp *big.Int
+	sv := NewBigIntValue(tmp, &tmp)
+	ps.VarValue(sv, name, optional, metadata)
+	return &tmp
+}
+
+// BigInt creates a parameter of type bigint.
+func BigInt(name string, optional bool, metadata interface{}) **big.Int {
+	return defaultParamSet.BigInt(name, optional, metadata)
+}
+
+// BigIntList creates a parameter of type bigint that captures all the remaining arguments.
+func (ps *ParamSet) BigIntList(name string, optional bool, metadata interface{}) *[]*big.Int {
+	minLength := 0
+	if !optional {
+		minLength = 1
+	}
+	return ps.BigIntListCustom(name, minLength, -1, metadata)
+}
+
+// BigIntList creates a parameter of type bigint that captures all the remaining arguments.
+func BigIntList(name string, optional bool, metadata interface{}) *[]*big.Int {
+	return defaultParamSet.BigIntList(name, optional, metadata)
+}
+
+// BigIntListCustom creates a parameter of type bigint that captures a list of the specified min and
+// max length from the remaining arguments
+func (ps *ParamSet) BigIntListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]*big.Int {
+	list := new(BigIntValueList)
+	ps.VarListCustom(list, name, minLength, maxLength, metadata)
+	return (*[]*big.Int)(list)
+}
+
+// BigIntListCustom creates a parameter of type bigint that captures a list of the specified min and
+// max length from the remaining arguments
+func BigIntListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]*big.Int {
+	return defaultParamSet.BigIntListCustom(name, minLength, maxLength, metadata)
+}
+
+// BigIntValueMap is a map of bigint that can receive a list of KEY=VALUE values
+type BigIntValueMap struct {
+	Values map[string]*big.Int
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *BigIntValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v *big.Int
+		err = NewBigIntValue(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]*big.Int)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]bigint
+func (m *BigIntValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *BigIntValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewBigIntValue(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// BigIntMap creates a parameter of type bigint that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) BigIntMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]*big.Int {
+	m := &BigIntValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// BigIntMap creates a parameter of type bigint that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func BigIntMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]*big.Int {
+	return defaultParamSet.BigIntMap(name, policy, optional, metadata)
+}
+
+// BigFloatValueList is a list of bigfloat that can receive a list of values
+type BigFloatValueList []*big.Float
+
+// Set sets the list of strings onto the string
+func (list *BigFloatValueList) Set(strings []string) error {
+	for _, s := range strings {
+		var v *big.Float
+		err := NewBigFloatValue(v, &v).Set(s)
+		if err != nil {
+			return err
+		}
+		*list = append(*list, v)
+	}
+	return nil
+}
+
+// Get returns the values of the list as a []bigfloat
+func (list *BigFloatValueList) Get() interface{} {
+	return []*big.Float(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *BigFloatValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewBigFloatValue((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
+// BigFloat creates a parameter of type bigfloat.
+func (ps *ParamSet) BigFloat(name string, optional bool, metadata interface{}) **big.Float {
+	var tmp *big.Float
+	sv := NewBigFloatValue(tmp, &tmp)
+	ps.VarValue(sv, name, optional, metadata)
+	return &tmp
+}
+
+// BigFloat creates a parameter of type bigfloat.
+func BigFloat(name string, optional bool, metadata interface{}) **big.Float {
+	return defaultParamSet.BigFloat(name, optional, metadata)
+}
+
+// BigFloatList creates a parameter of type bigfloat that captures all the remaining arguments.
+func (ps *ParamSet) BigFloatList(name string, optional bool, metadata interface{}) *[]*big.Float {
+	minLength := 0
+	if !optional {
+		minLength = 1
+	}
+	return ps.BigFloatListCustom(name, minLength, -1, metadata)
+}
+
+// BigFloatList creates a parameter of type bigfloat that captures all the remaining arguments.
+func BigFloatList(name string, optional bool, metadata interface{}) *[]*big.Float {
+	return defaultParamSet.BigFloatList(name, optional, metadata)
+}
+
+// BigFloatListCustom creates a parameter of type bigfloat that captures a list of the specified min and
+// max length from the remaining arguments
+func (ps *ParamSet) BigFloatListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]*big.Float {
+	list := new(BigFloatValueList)
+	ps.VarListCustom(list, name, minLength, maxLength, metadata)
+	return (*[]*big.Float)(list)
+}
+
+// BigFloatListCustom creates a parameter of type bigfloat that captures a list of the specified min and
+// max length from the remaining arguments
+func BigFloatListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]*big.Float {
+	return defaultParamSet.BigFloatListCustom(name, minLength, maxLength, metadata)
+}
+
+// BigFloatValueMap is a map of bigfloat that can receive a list of KEY=VALUE values
+type BigFloatValueMap struct {
+	Values map[string]*big.Float
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *BigFloatValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v *big.Float
+		err = NewBigFloatValue(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]*big.Float)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]bigfloat
+func (m *BigFloatValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *BigFloatValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewBigFloatValue(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// BigFloatMap creates a parameter of type bigfloat that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) BigFloatMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]*big.Float {
+	m := &BigFloatValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// BigFloatMap creates a parameter of type bigfloat that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func BigFloatMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]*big.Float {
+	return defaultParamSet.BigFloatMap(name, policy, optional, metadata)
+}
+
+// PercentageValueList is a list of percentage that can receive a list of values
+type PercentageValueList []Ratio
+
+// Set sets the list of strings onto the string
+func (list *PercentageValueList) Set(strings []string) error {
+	for _, s := range strings {
+		var v Ratio
+		err := NewPercentageValue(v, &v).Set(s)
+		if err != nil {
+			return err
+		}
+		*list = append(*list, v)
+	}
+	return nil
+}
+
+// Get returns the values of the list as a []percentage
+func (list *PercentageValueList) Get() interface{} {
+	return []Ratio(*list)
+}
+
+// MarshalArgs returns the values of the list formatted as strings
+func (list *PercentageValueList) MarshalArgs() ([]string, error) {
+	args := []string{}
+	for i := range *list {
+		args = append(args, NewPercentageValue((*list)[i], &(*list)[i]).String())
+	}
+	return args, nil
+}
+
+// Percentage creates a parameter of type percentage.
+func (ps *ParamSet) Percentage(name string, optional bool, metadata interface{}) *Ratio {
+	var tmp Ratio
+	sv := NewPercentageValue(tmp, &tmp)
+	ps.VarValue(sv, name, optional, metadata)
+	return &tmp
+}
+
+// Percentage creates a parameter of type percentage.
+func Percentage(name string, optional bool, metadata interface{}) *Ratio {
+	return defaultParamSet.Percentage(name, optional, metadata)
+}
+
+// PercentageList creates a parameter of type percentage that captures all the remaining arguments.
+func (ps *ParamSet) PercentageList(name string, optional bool, metadata interface{}) *[]Ratio {
+	minLength := 0
+	if !optional {
+		minLength = 1
+	}
+	return ps.PercentageListCustom(name, minLength, -1, metadata)
+}
+
+// PercentageList creates a parameter of type percentage that captures all the remaining arguments.
+func PercentageList(name string, optional bool, metadata interface{}) *[]Ratio {
+	return defaultParamSet.PercentageList(name, optional, metadata)
+}
+
+// PercentageListCustom creates a parameter of type percentage that captures a list of the specified min and
+// max length from the remaining arguments
+func (ps *ParamSet) PercentageListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]Ratio {
+	list := new(PercentageValueList)
+	ps.VarListCustom(list, name, minLength, maxLength, metadata)
+	return (*[]Ratio)(list)
+}
+
+// PercentageListCustom creates a parameter of type percentage that captures a list of the specified min and
+// max length from the remaining arguments
+func PercentageListCustom(name string, minLength int, maxLength int, metadata interface{}) *[]Ratio {
+	return defaultParamSet.PercentageListCustom(name, minLength, maxLength, metadata)
+}
+
+// PercentageValueMap is a map of percentage that can receive a list of KEY=VALUE values
+type PercentageValueMap struct {
+	Values map[string]Ratio
+	Policy DuplicatePolicy
+}
+
+// Set sets the list of KEY=VALUE strings onto the map
+func (m *PercentageValueMap) Set(strings []string) error {
+	for _, s := range strings {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		var v Ratio
+		err = NewPercentageValue(v, &v).Set(value)
+		if err != nil {
+			return err
+		}
+		if m.Values == nil {
+			m.Values = make(map[string]Ratio)
+		}
+		_, exists := m.Values[key]
+		store, err := m.Policy.check(key, exists)
+		if err != nil {
+			return err
+		}
+		if store {
+			m.Values[key] = v
+		}
+	}
+	return nil
+}
+
+// Get returns the values of the map as a map[string]percentage
+func (m *PercentageValueMap) Get() interface{} {
+	return m.Values
+}
+
+// MarshalArgs returns the entries of the map as KEY=VALUE strings, sorted by key
+func (m *PercentageValueMap) MarshalArgs() ([]string, error) {
+	keys := make([]string, 0, len(m.Values))
+	for key := range m.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := []string{}
+	for _, key := range keys {
+		value := m.Values[key]
+		arg, err := joinKeyValue(key, NewPercentageValue(value, &value).String())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// PercentageMap creates a parameter of type percentage that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func (ps *ParamSet) PercentageMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]Ratio {
+	m := &PercentageValueMap{Policy: policy}
+	ps.VarMap(m, name, optional, metadata)
+	return &m.Values
+}
+
+// PercentageMap creates a parameter of type percentage that captures the KEY=VALUE arguments at the
+// end of the remaining arguments.
+func PercentageMap(name string, policy DuplicatePolicy, optional bool, metadata interface{}) *map[string]Ratio {
+	return defaultParamSet.PercentageMap(name, policy, optional, metadata)
+}
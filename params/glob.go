@@ -0,0 +1,208 @@
+package params
+
+import (
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "reflect"
+    "sort"
+    "strings"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+// GlobPolicy specifies what happens to a glob pattern that does not match any files.
+type GlobPolicy int
+
+const (
+    // KeepUnmatched passes the pattern through literally, like most shells do
+    KeepUnmatched GlobPolicy = iota
+    // RejectUnmatched fails the parse
+    RejectUnmatched
+    // DropUnmatched removes the pattern, like the nullglob option in bash
+    DropUnmatched
+)
+
+// GlobExpansion records the arguments that a glob pattern was expanded into.
+type GlobExpansion struct {
+    Pattern string
+    Matches []string
+}
+
+// IsGlobPattern returns whether the argument contains any of the glob meta characters "*?[".
+func IsGlobPattern(arg string) bool {
+    return strings.ContainsAny(arg, "*?[")
+}
+
+// ExpandGlob returns the paths matching the pattern, sorted. In addition to the syntax of
+// filepath.Match, a "**" path segment matches zero or more directories. As in shells, files and
+// directories starting with "." are only matched if the segment of the pattern also starts with
+// ".", and symbolic links to directories are not followed by "**".
+func ExpandGlob(pattern string) ([]string, error) {
+    base := ""
+    rest := pattern
+    if filepath.IsAbs(pattern) {
+        volume := filepath.VolumeName(pattern)
+        base = volume + string(filepath.Separator)
+        rest = pattern[len(base):]
+    }
+    segments := strings.FieldsFunc(filepath.ToSlash(rest), func(r rune) bool { return r == '/' })
+    seen := make(map[string]bool)
+    var matches []string
+    err := globSegments(base, segments, func(path string) {
+        if !seen[path] {
+            seen[path] = true
+            matches = append(matches, path)
+        }
+    })
+    sort.Strings(matches)
+    return matches, err
+}
+
+func globSegments(base string, segments []string, found func(string)) error {
+    if len(segments) == 0 {
+        if base != "" { found(base) }
+        return nil
+    }
+    segment, rest := segments[0], segments[1:]
+    if !IsGlobPattern(segment) {
+        path := filepath.Join(base, segment)
+        if _, err := os.Lstat(path); err != nil {
+            return nil
+        }
+        return globSegments(path, rest, found)
+    }
+    dir := base
+    if dir == "" { dir = "." }
+    infos, err := ioutil.ReadDir(dir)
+    if err != nil {
+        // Like shells, directories that cannot be read simply don't match
+        return nil
+    }
+    if segment == "**" {
+        // Match zero directories
+        if err := globSegments(base, rest, found); err != nil { return err }
+    }
+    for _, info := range infos {
+        name := info.Name()
+        if strings.HasPrefix(name, ".") && !strings.HasPrefix(segment, ".") {
+            continue
+        }
+        if segment == "**" {
+            if info.IsDir() {
+                if err := globSegments(filepath.Join(base, name), segments, found); err != nil { return err }
+            }
+            continue
+        }
+        matched, err := filepath.Match(segment, name)
+        if err != nil { return err }
+        if matched {
+            if err := globSegments(filepath.Join(base, name), rest, found); err != nil { return err }
+        }
+    }
+    return nil
+}
+
+// expandGlobs expands the glob patterns in args according to the policy, returning the expanded
+// arguments and the expansions of each pattern.
+func expandGlobs(args []string, policy GlobPolicy) ([]string, []GlobExpansion, error) {
+    var expanded []string
+    var expansions []GlobExpansion
+    for _, arg := range args {
+        if !IsGlobPattern(arg) {
+            expanded = append(expanded, arg)
+            continue
+        }
+        matches, err := ExpandGlob(arg)
+        if err != nil { return nil, nil, i18n.Errorf(i18n.InvalidPattern, arg, err) }
+        if len(matches) == 0 {
+            switch policy {
+            case RejectUnmatched:
+                return nil, nil, validationErrorf(i18n.NoMatches, arg)
+            case KeepUnmatched:
+                matches = []string { arg }
+            }
+        }
+        expanded = append(expanded, matches...)
+        expansions = append(expansions, GlobExpansion{ arg, matches })
+    }
+    return expanded, expansions, nil
+}
+
+// GlobReceiver is a ValueReceiver that expands the glob patterns in the arguments, and passes the
+// expanded arguments to Receiver. This is useful when the program is invoked without a shell, so
+// patterns like "*.txt" are passed to the program literally.
+type GlobReceiver struct {
+    Receiver ValueReceiver
+    Policy GlobPolicy
+
+    // Expansions records the patterns that were expanded, and what they were expanded into
+    Expansions []GlobExpansion
+
+    // receiverDefaults is the snapshot of the Receiver, if it does not implement Resetter
+    receiverDefaults reflect.Value
+}
+
+// Reset resets the Receiver according to the policy and clears the Expansions.
+func (g *GlobReceiver) Reset(policy ResetPolicy) {
+    resetValue(g.Receiver, &g.receiverDefaults, policy)
+    g.Expansions = nil
+}
+
+// Set expands the glob patterns in args and sets them onto the Receiver
+func (g *GlobReceiver) Set(args []string) error {
+    expanded, expansions, err := expandGlobs(args, g.Policy)
+    if err != nil { return err }
+    g.Expansions = append(g.Expansions, expansions...)
+    if len(expanded) == 0 {
+        return nil
+    }
+    return g.Receiver.Set(expanded)
+}
+
+// GlobValueList is a list of strings that expands the glob patterns in the values it receives.
+type GlobValueList struct {
+    Values []string
+    Policy GlobPolicy
+
+    // Expansions records the patterns that were expanded, and what they were expanded into
+    Expansions []GlobExpansion
+}
+
+// Set expands the glob patterns in the list of strings and appends them to the list
+func (list *GlobValueList) Set(strings []string) error {
+    expanded, expansions, err := expandGlobs(strings, list.Policy)
+    if err != nil { return err }
+    list.Values = append(list.Values, expanded...)
+    list.Expansions = append(list.Expansions, expansions...)
+    return nil
+}
+
+// VarListGlob defines a parameter list using ValueReceiver that captures all the remaining
+// arguments, expanding the glob patterns in them before they are passed to the ValueReceiver. The
+// returned GlobReceiver records the expansions.
+func (ps *ParamSet) VarListGlob(value ValueReceiver, name string, policy GlobPolicy, optional bool, metadata interface{}) *GlobReceiver {
+    g := &GlobReceiver{ Receiver: value, Policy: policy }
+    ps.VarList(g, name, optional, metadata)
+    return g
+}
+
+// VarListGlob defines a parameter list using ValueReceiver that captures all the remaining
+// arguments, expanding the glob patterns in them on the DefaultParamSet.
+func VarListGlob(value ValueReceiver, name string, policy GlobPolicy, optional bool, metadata interface{}) *GlobReceiver {
+    return defaultParamSet.VarListGlob(value, name, policy, optional, metadata)
+}
+
+// StringListGlob creates a parameter of type string that captures all the remaining arguments,
+// expanding the glob patterns in them.
+func (ps *ParamSet) StringListGlob(name string, policy GlobPolicy, optional bool, metadata interface{}) *GlobValueList {
+    list := &GlobValueList{ Policy: policy }
+    ps.VarList(list, name, optional, metadata)
+    return list
+}
+
+// StringListGlob creates a parameter of type string that captures all the remaining arguments,
+// expanding the glob patterns in them on the DefaultParamSet.
+func StringListGlob(name string, policy GlobPolicy, optional bool, metadata interface{}) *GlobValueList {
+    return defaultParamSet.StringListGlob(name, policy, optional, metadata)
+}
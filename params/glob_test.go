@@ -0,0 +1,107 @@
+package params
+
+import (
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "reflect"
+    "testing"
+)
+
+func globFixture(t *testing.T) string {
+    dir := tempDir(t)
+    os.MkdirAll(filepath.Join(dir, "a", "b"), 0755)
+    for _, file := range []string { "z.txt", ".hidden.txt", "a/x.txt", "a/b/y.txt", "a/b/y.go" } {
+        ioutil.WriteFile(filepath.Join(dir, file), nil, 0644)
+    }
+    return dir
+}
+
+func TestExpandGlob(t *testing.T) {
+    dir := globFixture(t)
+    defer os.RemoveAll(dir)
+
+    join := func(paths ...string) []string {
+        for i, path := range paths { paths[i] = filepath.Join(dir, path) }
+        return paths
+    }
+
+    cases := []struct {
+        pattern string
+        expected []string
+    }{
+        { "*.txt", join("z.txt") },
+        { ".*.txt", join(".hidden.txt") },
+        { "**/*.txt", join("a/b/y.txt", "a/x.txt", "z.txt") },
+        { "a/**/y.*", join("a/b/y.go", "a/b/y.txt") },
+        { "*/b", join("a/b") },
+        { "*.md", nil },
+    }
+    for _, c := range cases {
+        matches, err := ExpandGlob(filepath.Join(dir, c.pattern))
+        if err != nil { t.Errorf("Error for %s: %v", c.pattern, err) }
+        if !reflect.DeepEqual(matches, c.expected) {
+            t.Errorf("%s should match %v, but was %v", c.pattern, c.expected, matches)
+        }
+    }
+}
+
+func TestStringListGlob(t *testing.T) {
+
+    // Test setup
+
+    dir := globFixture(t)
+    defer os.RemoveAll(dir)
+    p := new(ParamSet)
+    files := p.StringListGlob("files", KeepUnmatched, false, nil)
+
+    // Test execution
+
+    pattern := filepath.Join(dir, "a", "*", "*.txt")
+    unmatched := filepath.Join(dir, "*.md")
+    err := p.Parse([]string { "literal", pattern, unmatched })
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    expectedValues := []string { "literal", filepath.Join(dir, "a", "b", "y.txt"), unmatched }
+    if !reflect.DeepEqual(files.Values, expectedValues) {
+        t.Errorf("files should be %v, but was %v", expectedValues, files.Values)
+    }
+    expectedExpansions := []GlobExpansion {
+        { pattern, []string { filepath.Join(dir, "a", "b", "y.txt") } },
+        { unmatched, []string { unmatched } },
+    }
+    if !reflect.DeepEqual(files.Expansions, expectedExpansions) {
+        t.Errorf("Unexpected expansions: %v", files.Expansions)
+    }
+}
+
+func TestGlobUnmatchedPolicies(t *testing.T) {
+    dir := globFixture(t)
+    defer os.RemoveAll(dir)
+    unmatched := filepath.Join(dir, "*.md")
+
+    t.Run("reject", func (t *testing.T) {
+        p := new(ParamSet)
+        p.StringListGlob("files", RejectUnmatched, true, nil)
+        err := p.Parse([]string { unmatched })
+
+        if err == nil || err.Error() != `No matches for pattern "` + unmatched + `"` {
+            t.Errorf("Error should be thrown, but was: %v", err)
+        }
+    })
+
+    t.Run("drop", func (t *testing.T) {
+        p := new(ParamSet)
+        var list IntValueList
+        g := p.VarListGlob(&list, "numbers", DropUnmatched, true, nil)
+        err := p.Parse([]string { "1", unmatched, "2" })
+
+        if err != nil { t.Errorf("Unexpected error: %v", err) }
+        if !reflect.DeepEqual([]int(list), []int { 1, 2 }) { t.Errorf("Unexpected list: %v", list) }
+        if len(g.Expansions) != 1 || g.Expansions[0].Matches != nil {
+            t.Errorf("Unexpected expansions: %v", g.Expansions)
+        }
+    })
+}
@@ -0,0 +1,228 @@
+package params
+
+import (
+    "fmt"
+    "math/big"
+    "net"
+    "net/url"
+    "os"
+    "reflect"
+    "regexp"
+    "strings"
+    "time"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+// GroupValueList is a ValueReceiver that converts each group of arguments into a struct, which is
+// appended to a slice of structs. Each exported field of the struct is a slot in the group, in the
+// order they are declared. Slots are named by the "param" struct tag, or the field name otherwise.
+//
+// Fields can be of any type supported by the Value types in this package, or any type whose pointer
+// implements Value.
+type GroupValueList struct {
+    slice reflect.Value
+    fields []int
+    slots []string
+    defaults reflect.Value
+}
+
+// NewGroupValueList creates a GroupValueList that appends to the slice pointed to by dest, which
+// must be a pointer to a slice of structs. NewGroupValueList panics if dest is not of that type.
+func NewGroupValueList(dest interface{}) *GroupValueList {
+    ptr := reflect.ValueOf(dest)
+    if ptr.Kind() != reflect.Ptr || ptr.Elem().Kind() != reflect.Slice || ptr.Elem().Type().Elem().Kind() != reflect.Struct {
+        panic(fmt.Sprintf("GroupValueList requires a pointer to a slice of structs, but was %T", dest))
+    }
+    list := &GroupValueList{ slice: ptr.Elem(), defaults: cloneValue(ptr.Elem()) }
+    elemType := list.slice.Type().Elem()
+    for i := 0; i < elemType.NumField(); i++ {
+        field := elemType.Field(i)
+        if field.PkgPath != "" {
+            // Unexported field
+            continue
+        }
+        slot := field.Tag.Get("param")
+        if slot == "" { slot = strings.ToLower(field.Name) }
+        list.fields = append(list.fields, i)
+        list.slots = append(list.slots, slot)
+    }
+    if len(list.fields) == 0 {
+        panic(fmt.Sprintf("%v does not have any exported fields", elemType))
+    }
+    return list
+}
+
+// GroupSize returns the number of arguments in each group.
+func (list *GroupValueList) GroupSize() int {
+    return len(list.fields)
+}
+
+// Reset sets the slice back to the structs it contained when the GroupValueList was created, or to
+// nil for ClearValues.
+func (list *GroupValueList) Reset(policy ResetPolicy) {
+    if policy == ClearValues {
+        list.slice.Set(reflect.Zero(list.slice.Type()))
+    } else {
+        list.slice.Set(cloneValue(list.defaults))
+    }
+}
+
+// Get returns the slice of structs.
+func (list *GroupValueList) Get() interface{} {
+    return list.slice.Interface()
+}
+
+// Slots returns the names of the slots in each group.
+func (list *GroupValueList) Slots() []string {
+    return list.slots
+}
+
+// Set converts the strings, whose length must be a multiple of the group size, into structs and
+// appends them to the slice.
+func (list *GroupValueList) Set(strings []string) error {
+    size := list.GroupSize()
+    if len(strings) % size != 0 {
+        return i18n.Errorf(i18n.GroupSize, size, len(strings))
+    }
+    for g := 0; g < len(strings) / size; g++ {
+        elem := reflect.New(list.slice.Type().Elem()).Elem()
+        for s, fieldIndex := range list.fields {
+            value, err := fieldValue(elem.Field(fieldIndex))
+            if err == nil {
+                err = value.Set(strings[g * size + s])
+            }
+            if err != nil {
+                return i18n.Errorf(i18n.GroupSlot, g + 1, list.slots[s], err)
+            }
+        }
+        list.slice.Set(reflect.Append(list.slice, elem))
+    }
+    return nil
+}
+
+// fieldValue returns a Value that sets the given addressable field.
+func fieldValue(field reflect.Value) (Value, error) {
+    if v, ok := field.Addr().Interface().(Value); ok {
+        return v, nil
+    }
+    switch p := field.Addr().Interface().(type) {
+    case *bool:
+        return (*BoolValue)(p), nil
+    case *string:
+        return (*StringValue)(p), nil
+    case *int:
+        return (*IntValue)(p), nil
+    case *int64:
+        return (*Int64Value)(p), nil
+    case *uint:
+        return (*UintValue)(p), nil
+    case *uint64:
+        return (*Uint64Value)(p), nil
+    case *float64:
+        return (*Float64Value)(p), nil
+    case *time.Duration:
+        return (*DurationValue)(p), nil
+    case *url.URL:
+        return (*URLValue)(p), nil
+    case *net.IP:
+        return (*IPValue)(p), nil
+    case *net.IPNet:
+        return (*IPNetValue)(p), nil
+    case **regexp.Regexp:
+        return NewRegexpValue(*p, p), nil
+    case *ByteSize:
+        return (*SizeValue)(p), nil
+    case *os.FileMode:
+        return (*FileModeValue)(p), nil
+    case **big.Int:
+        return NewBigIntValue(*p, p), nil
+    case **big.Float:
+        return NewBigFloatValue(*p, p), nil
+    case *Ratio:
+        return (*PercentageValue)(p), nil
+    }
+    return nil, fmt.Errorf("Unsupported field type %v", field.Type())
+}
+
+// groupParamSpec captures arguments in multiples of the group size
+type groupParamSpec struct {
+    commonParamSpec
+    slots []string
+}
+
+var _ ParamSpec = (*groupParamSpec)(nil)
+
+func (param *groupParamSpec) CaptureLength(argvSlice []string) (int, error) {
+    size := len(param.slots)
+    l, _ := param.commonParamSpec.CaptureLength(argvSlice)
+    return l - l % size, nil
+}
+
+func (param *groupParamSpec) Set(args []string) error {
+    if err := param.value.Set(args); err != nil {
+        return i18n.Errorf(i18n.ArgumentErrorPrefix, param.name, err)
+    }
+    return nil
+}
+
+func (param *groupParamSpec) Synopsis() string {
+    slots := make([]string, len(param.slots))
+    for i, slot := range param.slots {
+        slots[i] = "<" + slot + ">"
+    }
+    s := strings.Join(slots, " ")
+    if param.maxLength != len(param.slots) {
+        s = "(" + s + ")..."
+    }
+    if param.minLength == 0 {
+        s = "[" + s + "]"
+    }
+    return s
+}
+
+// NewGroupParamSpec creates a parameter list spec using GroupValueList that captures between
+// minGroups and maxGroups groups of arguments from the remaining arguments. maxGroups can be -1 for
+// an unlimited number of groups.
+func NewGroupParamSpec(value *GroupValueList, name string, minGroups int, maxGroups int, metadata interface{}) ParamSpec {
+    size := value.GroupSize()
+    maxLength := -1
+    if maxGroups >= 0 { maxLength = maxGroups * size }
+    return &groupParamSpec{
+        commonParamSpec: commonParamSpec{
+            name: name,
+            minLength: minGroups * size,
+            maxLength: maxLength,
+            value: value,
+            metadata: metadata,
+        },
+        slots: value.Slots(),
+    }
+}
+
+// GroupList defines a parameter that captures all the remaining arguments in groups, converting
+// each group into a struct appended to dest, which must be a pointer to a slice of structs. See
+// GroupValueList for how the struct fields are converted.
+func (ps *ParamSet) GroupList(dest interface{}, name string, optional bool, metadata interface{}) {
+    minGroups := 0
+    if !optional { minGroups = 1 }
+    ps.GroupListCustom(dest, name, minGroups, -1, metadata)
+}
+
+// GroupList defines a parameter that captures all the remaining arguments in groups on the
+// DefaultParamSet.
+func GroupList(dest interface{}, name string, optional bool, metadata interface{}) {
+    defaultParamSet.GroupList(dest, name, optional, metadata)
+}
+
+// GroupListCustom defines a parameter that captures between minGroups and maxGroups groups from
+// the remaining arguments, converting each group into a struct appended to dest.
+func (ps *ParamSet) GroupListCustom(dest interface{}, name string, minGroups int, maxGroups int, metadata interface{}) {
+    ps.Param(NewGroupParamSpec(NewGroupValueList(dest), name, minGroups, maxGroups, metadata))
+}
+
+// GroupListCustom defines a parameter that captures between minGroups and maxGroups groups from
+// the remaining arguments on the DefaultParamSet.
+func GroupListCustom(dest interface{}, name string, minGroups int, maxGroups int, metadata interface{}) {
+    defaultParamSet.GroupListCustom(dest, name, minGroups, maxGroups, metadata)
+}
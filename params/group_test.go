@@ -0,0 +1,78 @@
+package params
+
+import (
+    "reflect"
+    "testing"
+)
+
+type copyPair struct {
+    Src string
+    Dst string `param:"destination"`
+    Mode int
+}
+
+func TestGroupListParsing(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    var pairs []copyPair
+    p.GroupList(&pairs, "pairs", false, nil)
+    dryRun := p.String("dryRun", true, nil)
+
+    // Test execution
+
+    err := p.Parse([]string { "a", "b", "1", "c", "d", "2", "now" })
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if !reflect.DeepEqual(pairs, []copyPair { { "a", "b", 1 }, { "c", "d", 2 } }) {
+        t.Errorf("Unexpected pairs: %v", pairs)
+    }
+    if *dryRun != "now" { t.Errorf(`dryRun should be "now", but was %s`, *dryRun) }
+}
+
+func TestGroupListCapturesWholeGroups(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    var pairs []copyPair
+    p.GroupListCustom(&pairs, "pairs", 0, 1, nil)
+    rest := p.StringList("rest", true, nil)
+
+    // Execution
+    err := p.Parse([]string { "a", "b", "1", "c", "d" })
+
+    // Assertions
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    if !reflect.DeepEqual(pairs, []copyPair { { "a", "b", 1 } }) { t.Errorf("Unexpected pairs: %v", pairs) }
+    if !reflect.DeepEqual(*rest, []string { "c", "d" }) { t.Errorf("Unexpected rest: %v", *rest) }
+}
+
+func TestGroupListConversionError(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    var pairs []copyPair
+    p.GroupList(&pairs, "pairs", false, nil)
+
+    // Execution
+    err := p.Parse([]string { "a", "b", "1", "c", "d", "x" })
+
+    // Assertions
+    expected := `Argument "pairs": Group 2, slot "mode": strconv.ParseInt: parsing "x": invalid syntax`
+    if err == nil || err.Error() != expected {
+        t.Errorf("Unexpected error: %v", err)
+    }
+}
+
+func TestGroupListSynopsis(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    var pairs []copyPair
+    p.GroupList(&pairs, "pairs", true, nil)
+
+    // Assertions
+    if synopsis := p.Synopsis(); synopsis != "[(<src> <destination> <mode>)...]" {
+        t.Errorf("Unexpected synopsis: %s", synopsis)
+    }
+}
@@ -0,0 +1,152 @@
+package params
+
+import (
+    "fmt"
+    "io"
+    "reflect"
+    "strings"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+// Help is the standard metadata of a ParamSpec, which is understood by the usage and help rendering
+// of this package. It can be passed as the metadata of any of the constructors, either as a Help or
+// a *Help. Any other metadata value is still accepted, and can be retrieved using ExtraOf.
+type Help struct {
+    // Short is a one line description of the parameter
+    Short string
+    // Long is a longer description, which can span multiple lines
+    Long string
+    // Placeholder replaces the name of the parameter in the synopsis, e.g. "FILE" for "<FILE>"
+    Placeholder string
+    // Group is the heading the parameter is listed under in the help
+    Group string
+    // Examples are example values of the parameter
+    Examples []string
+    // EnvVar is the name of an environment variable that can be used instead of the argument
+    EnvVar string
+    // Deprecated is the deprecation message. If it is not empty, a warning is logged when the
+    // parameter receives any values.
+    Deprecated string
+    // Extra holds any additional user-defined metadata
+    Extra interface{}
+}
+
+// HelpOf returns the Help in the metadata of the ParamSpec. If the metadata is a string, it is used
+// as the short description. Other metadata values are returned in the Extra field.
+func HelpOf(paramSpec ParamSpec) Help {
+    switch metadata := paramSpec.Metadata().(type) {
+    case nil:
+        return Help{}
+    case Help:
+        return metadata
+    case *Help:
+        if metadata == nil { return Help{} }
+        return *metadata
+    case string:
+        return Help{ Short: metadata }
+    default:
+        return Help{ Extra: metadata }
+    }
+}
+
+// ExtraOf finds the user-defined metadata of the ParamSpec, which is either the metadata itself or
+// the Extra field of its Help, and if it is assignable to the value pointed to by target, sets
+// target to it and returns true. Otherwise, it returns false. Like errors.As, ExtraOf panics if
+// target is not a non-nil pointer.
+//
+//   var info *MyInfo
+//   if params.ExtraOf(spec, &info) { ... }
+func ExtraOf(paramSpec ParamSpec, target interface{}) bool {
+    ptr := reflect.ValueOf(target)
+    if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+        panic(fmt.Sprintf("ExtraOf target must be a non-nil pointer, but was %T", target))
+    }
+    extra := HelpOf(paramSpec).Extra
+    if extra == nil {
+        return false
+    }
+    value := reflect.ValueOf(extra)
+    if !value.Type().AssignableTo(ptr.Elem().Type()) {
+        return false
+    }
+    ptr.Elem().Set(value)
+    return true
+}
+
+// deprecationOf returns the deprecation message of the ParamSpec, from either DeprecatedSpec or its
+// Help.
+func deprecationOf(paramSpec ParamSpec) string {
+    if deprecated, ok := paramSpec.(DeprecatedSpec); ok && deprecated.Deprecation() != "" {
+        return deprecated.Deprecation()
+    }
+    return HelpOf(paramSpec).Deprecated
+}
+
+// displayName returns the name of the ParamSpec displayed in the synopsis
+func displayName(paramSpec ParamSpec) string {
+    if placeholder := HelpOf(paramSpec).Placeholder; placeholder != "" {
+        return placeholder
+    }
+    return paramSpec.String()
+}
+
+// PrintDefaults prints the help of all the parameters in the ParamSet that are not hidden to w,
+// grouped by Help.Group, in the format
+//
+//   <inputFiles>...
+//         the files to process
+func (ps *ParamSet) PrintDefaults(w io.Writer) {
+    if ps == nil {
+        return
+    }
+    var groups []string
+    specsByGroup := make(map[string][]ParamSpec)
+    for _, paramSpec := range *ps {
+        if IsHidden(paramSpec) {
+            continue
+        }
+        group := HelpOf(paramSpec).Group
+        if _, ok := specsByGroup[group]; !ok {
+            groups = append(groups, group)
+        }
+        specsByGroup[group] = append(specsByGroup[group], paramSpec)
+    }
+    for i, group := range groups {
+        if group != "" {
+            if i > 0 { fmt.Fprintln(w) }
+            fmt.Fprintf(w, "%s:\n", group)
+        }
+        for _, paramSpec := range specsByGroup[group] {
+            printSpecHelp(w, paramSpec)
+        }
+    }
+}
+
+// PrintDefaults prints the help of all the parameters in the DefaultParamSet to w.
+func PrintDefaults(w io.Writer) {
+    defaultParamSet.PrintDefaults(w)
+}
+
+func printSpecHelp(w io.Writer, paramSpec ParamSpec) {
+    fmt.Fprintf(w, "  %s\n", strings.Trim(SpecSynopsis(paramSpec), "[]"))
+    if description := Description(paramSpec); description != "" {
+        fmt.Fprintf(w, "        %s\n", strings.Replace(description, "\n", "\n        ", -1))
+    }
+}
+
+// Description returns the description of the ParamSpec displayed in the help, from its Help: the
+// short and long descriptions, the examples, the environment variable and the deprecation, each
+// on its own line.
+func Description(paramSpec ParamSpec) string {
+    help := HelpOf(paramSpec)
+    var lines []string
+    if help.Short != "" { lines = append(lines, help.Short) }
+    if help.Long != "" { lines = append(lines, help.Long) }
+    if len(help.Examples) > 0 { lines = append(lines, i18n.Sprintf(i18n.ExamplesLabel) + " " + strings.Join(help.Examples, ", ")) }
+    if help.EnvVar != "" { lines = append(lines, i18n.Sprintf(i18n.EnvironmentLabel) + " $" + help.EnvVar) }
+    if deprecation := deprecationOf(paramSpec); deprecation != "" {
+        lines = append(lines, i18n.Sprintf(i18n.DeprecatedLabel) + " " + deprecation)
+    }
+    return strings.Join(lines, "\n")
+}
@@ -0,0 +1,105 @@
+package params
+
+import (
+    "bytes"
+    "reflect"
+    "testing"
+)
+
+type customMetadata struct {
+    Weight int
+}
+
+func TestHelpOf(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    p.String("none", true, nil)
+    p.String("help", true, Help{ Short: "value help" })
+    p.String("pointer", true, &Help{ Short: "pointer help" })
+    p.String("string", true, "string help")
+    p.String("custom", true, customMetadata{ 3 })
+
+    // Assertions
+    expected := []Help {
+        {},
+        { Short: "value help" },
+        { Short: "pointer help" },
+        { Short: "string help" },
+        { Extra: customMetadata{ 3 } },
+    }
+    for i, paramSpec := range *p {
+        if help := HelpOf(paramSpec); !reflect.DeepEqual(help, expected[i]) {
+            t.Errorf(`Help of "%s" should be %v, but was %v`, paramSpec, expected[i], help)
+        }
+    }
+}
+
+func TestExtraOf(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    p.String("direct", true, customMetadata{ 1 })
+    p.String("extra", true, Help{ Short: "help", Extra: customMetadata{ 2 } })
+    p.String("other", true, "help")
+
+    // Execution
+    var direct, extra, other customMetadata
+    directOk := ExtraOf((*p)[0], &direct)
+    extraOk := ExtraOf((*p)[1], &extra)
+    otherOk := ExtraOf((*p)[2], &other)
+
+    // Assertions
+    if !directOk || direct.Weight != 1 { t.Errorf("Unexpected metadata: %v %v", directOk, direct) }
+    if !extraOk || extra.Weight != 2 { t.Errorf("Unexpected metadata: %v %v", extraOk, extra) }
+    if otherOk { t.Errorf("Metadata should not be found") }
+}
+
+func TestHelpPlaceholderAndDeprecation(t *testing.T) {
+    // Setup
+    warnings, restore := useRecordingLogger()
+    defer restore()
+    p := new(ParamSet)
+    p.String("command", false, Help{ Placeholder: "CMD", Deprecated: "use --command" })
+    p.StringList("inputFiles", true, &Help{ Placeholder: "FILE" })
+
+    // Execution
+    err := p.Parse([]string { "a" })
+
+    // Assertions
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    if synopsis := p.Synopsis(); synopsis != "<CMD> [<FILE>...]" { t.Errorf("Unexpected synopsis: %s", synopsis) }
+    expected := []string { `Warning: argument "command" is deprecated: use --command` }
+    if !reflect.DeepEqual([]string(*warnings), expected) { t.Errorf("Unexpected warnings: %v", *warnings) }
+}
+
+func TestPrintDefaults(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    p.String("command", false, Help{ Short: "the command to run", Examples: []string { "build", "test" } })
+    p.Param(Hidden(NewParamSpec(new(StringValueList), "secret", true, nil)))
+    p.StringList("files", true, Help{ Short: "files to process", Long: "Each file is\nprocessed in order", Group: "Input", EnvVar: "FILES" })
+    p.String("output", true, Help{ Group: "Output", Deprecated: "use -o" })
+
+    // Execution
+    var buf bytes.Buffer
+    p.PrintDefaults(&buf)
+
+    // Assertions
+    expected := `  <command>
+        the command to run
+        Examples: build, test
+
+Input:
+  <files>...
+        files to process
+        Each file is
+        processed in order
+        Environment: $FILES
+
+Output:
+  <output>
+        Deprecated: use -o
+`
+    if buf.String() != expected {
+        t.Errorf("Unexpected defaults:\n%s", buf.String())
+    }
+}
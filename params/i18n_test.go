@@ -0,0 +1,29 @@
+package params
+
+import (
+    "testing"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+func init() {
+    // The tests compare the English messages, regardless of the locale of the environment
+    i18n.SetLocale("en")
+}
+
+func TestLocalizedErrors(t *testing.T) {
+    // Setup
+    i18n.SetLocale("de_DE.UTF-8")
+    defer i18n.SetLocale("en")
+    p := new(ParamSet)
+    p.String("source", false, nil)
+    p.Choice("mode", []string { "copy", "move" }, true, nil)
+
+    // Assertions
+    if err := p.Parse([]string {}); err == nil || err.Error() != `Erforderliches Argument "source" fehlt` {
+        t.Errorf("Unexpected error: %v", err)
+    }
+    if err := p.Parse([]string { "a", "mvoe" }); err == nil || err.Error() != `Ungültige Auswahl "mvoe", erwartet wird eine von copy, move. Meinten Sie "move"?` {
+        t.Errorf("Unexpected error: %v", err)
+    }
+}
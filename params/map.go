@@ -0,0 +1,105 @@
+package params
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+// DuplicatePolicy specifies what a map parameter does when the same key is given more than once.
+type DuplicatePolicy int
+
+const (
+    // RejectDuplicates fails the parse when a key is given more than once
+    RejectDuplicates DuplicatePolicy = iota
+    // KeepFirst keeps the value of the first occurrence of a key
+    KeepFirst
+    // KeepLast keeps the value of the last occurrence of a key
+    KeepLast
+)
+
+// check returns whether the value of the key should be stored, given whether the key already
+// exists in the map.
+func (policy DuplicatePolicy) check(key string, exists bool) (bool, error) {
+    if !exists {
+        return true, nil
+    }
+    switch policy {
+    case KeepFirst:
+        return false, nil
+    case KeepLast:
+        return true, nil
+    default:
+        return false, validationErrorf(i18n.DuplicateKey, key)
+    }
+}
+
+// splitKeyValue splits the argument on the first "=" into the key and the value.
+func splitKeyValue(arg string) (string, string, error) {
+    i := strings.Index(arg, "=")
+    if i <= 0 {
+        return "", "", i18n.Errorf(i18n.ExpectedKeyValue, arg)
+    }
+    return arg[:i], arg[i+1:], nil
+}
+
+// joinKeyValue returns the KEY=VALUE argument that splitKeyValue splits into key and value
+func joinKeyValue(key string, value string) (string, error) {
+    if key == "" || strings.Contains(key, "=") {
+        return "", fmt.Errorf(`Key "%s" cannot be written as KEY=VALUE`, key)
+    }
+    return key + "=" + value, nil
+}
+
+func isKeyValue(arg string) bool {
+    return strings.Index(arg, "=") > 0
+}
+
+// mapParamSpec is a ParamSpec that captures KEY=VALUE arguments. It reserves the KEY=VALUE
+// arguments at the end of argv, so that a list parameter before it does not capture them.
+type mapParamSpec struct {
+    patternParamSpec
+}
+
+var _ Reserver = (*mapParamSpec)(nil)
+
+func (param *mapParamSpec) ReserveLength(argvSlice []string) int {
+    l := 0
+    for l < len(argvSlice) && (param.maxLength < 0 || l < param.maxLength) && isKeyValue(argvSlice[len(argvSlice) - l - 1]) {
+        l++
+    }
+    return l
+}
+
+// NewMapParamSpec creates a parameter list spec using ValueReceiver that captures the KEY=VALUE
+// arguments at the end of the remaining arguments. The ValueReceiver is responsible for splitting
+// the arguments into keys and values, like StringValueMap.
+func NewMapParamSpec(value ValueReceiver, name string, optional bool, metadata interface{}) ParamSpec {
+    minLength := 0
+    if !optional { minLength = 1 }
+    return &mapParamSpec{
+        patternParamSpec{
+            commonParamSpec: commonParamSpec{
+                name: name,
+                minLength: minLength,
+                maxLength: -1,
+                value: value,
+                metadata: metadata,
+            },
+            match: isKeyValue,
+        },
+    }
+}
+
+// VarMap defines a parameter using ValueReceiver that captures the KEY=VALUE arguments at the end
+// of the remaining arguments.
+func (ps *ParamSet) VarMap(value ValueReceiver, name string, optional bool, metadata interface{}) {
+    ps.Param(NewMapParamSpec(value, name, optional, metadata))
+}
+
+// VarMap defines a parameter using ValueReceiver that captures the KEY=VALUE arguments at the end
+// of the remaining arguments on the DefaultParamSet.
+func VarMap(value ValueReceiver, name string, optional bool, metadata interface{}) {
+    defaultParamSet.VarMap(value, name, optional, metadata)
+}
@@ -0,0 +1,98 @@
+package params
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestStringMapAfterList(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    files := p.StringList("files", true, nil)
+    vars := p.StringMap("vars", RejectDuplicates, true, nil)
+
+    // Test execution
+
+    err := p.Parse([]string { "a", "b", "X=1", "Y=a=b" })
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if !reflect.DeepEqual(*files, []string { "a", "b" }) {
+        t.Errorf(`files should be ["a", "b"], but was %v`, *files)
+    }
+    if !reflect.DeepEqual(*vars, map[string]string { "X": "1", "Y": "a=b" }) {
+        t.Errorf("Unexpected vars: %v", *vars)
+    }
+}
+
+func TestMapOnlyReservesTrailingPairs(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    files := p.StringList("files", true, nil)
+    vars := p.StringMap("vars", RejectDuplicates, true, nil)
+
+    // Execution
+    err := p.Parse([]string { "a", "X=1", "b" })
+
+    // Assertions
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    if !reflect.DeepEqual(*files, []string { "a", "X=1", "b" }) { t.Errorf("Unexpected files: %v", *files) }
+    if *vars != nil { t.Errorf("vars should be nil, but was %v", *vars) }
+}
+
+func TestMapDoesNotStealRequiredArgs(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    files := p.StringList("files", false, nil)
+    vars := p.StringMap("vars", RejectDuplicates, true, nil)
+
+    // Execution
+    err := p.Parse([]string { "X=1" })
+
+    // Assertions
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    if !reflect.DeepEqual(*files, []string { "X=1" }) { t.Errorf("Unexpected files: %v", *files) }
+    if len(*vars) != 0 { t.Errorf("vars should be empty, but was %v", *vars) }
+}
+
+func TestMapDuplicates(t *testing.T) {
+    argv := []string { "X=1", "X=2" }
+
+    t.Run("reject", func (t *testing.T) {
+        p := new(ParamSet)
+        p.IntMap("vars", RejectDuplicates, false, nil)
+        err := p.Parse(argv)
+
+        if err == nil || err.Error() != `Duplicate key "X"` {
+            t.Errorf("Error should be thrown, but was: %v", err)
+        }
+    })
+
+    t.Run("keep first", func (t *testing.T) {
+        p := new(ParamSet)
+        vars := p.IntMap("vars", KeepFirst, false, nil)
+        err := p.Parse(argv)
+
+        if err != nil { t.Errorf("Unexpected error: %v", err) }
+        if !reflect.DeepEqual(*vars, map[string]int { "X": 1 }) { t.Errorf("Unexpected vars: %v", *vars) }
+    })
+
+    t.Run("keep last", func (t *testing.T) {
+        p := new(ParamSet)
+        vars := p.IntMap("vars", KeepLast, false, nil)
+        err := p.Parse(argv)
+
+        if err != nil { t.Errorf("Unexpected error: %v", err) }
+        if !reflect.DeepEqual(*vars, map[string]int { "X": 2 }) { t.Errorf("Unexpected vars: %v", *vars) }
+    })
+}
+
+func TestIntValueMapConversionError(t *testing.T) {
+    m := new(IntValueMap)
+    err := m.Set([]string { "X=abc" })
+
+    if err == nil { t.Errorf("Error should be thrown") }
+}
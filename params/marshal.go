@@ -0,0 +1,223 @@
+package params
+
+import (
+    "fmt"
+    "reflect"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+// ArgsMarshaler is implemented by ParamSpecs, ValueReceivers and Values that can return the
+// arguments that would set their current value, the reverse of Set. Values that only implement
+// fmt.Stringer, like most Values in this package, are marshaled as the single argument returned by
+// String.
+type ArgsMarshaler interface {
+    MarshalArgs() ([]string, error)
+}
+
+// MarshalArgs returns a canonical argv that parses back to the current values of the ParamSet, e.g.
+// to spawn a child process with the same arguments. The arguments of each ParamSpec are returned in
+// order. Optional parameters whose values have not changed since they were defined are omitted,
+// unless that would change how the arguments are allocated.
+//
+// An error is returned if a value cannot be marshaled, or if the arguments would be allocated to
+// different parameters when they are parsed, e.g. if a list is followed by a parameter whose
+// arguments it would capture.
+func (ps *ParamSet) MarshalArgs() ([]string, error) {
+    if ps == nil {
+        return []string {}, nil
+    }
+    specArgs := make([][]string, len(*ps))
+    for i, paramSpec := range *ps {
+        args, err := marshalSpec(paramSpec)
+        if err != nil { return nil, fmt.Errorf("Cannot marshal %s: %w", paramSpec, err) }
+        specArgs[i] = args
+    }
+    argv, ok := ps.joinArgs(specArgs, true)
+    if !ok {
+        argv, ok = ps.joinArgs(specArgs, false)
+    }
+    if !ok {
+        return nil, fmt.Errorf("Arguments %s would not be allocated to the same parameters", quoteArgs(argv))
+    }
+    return argv, nil
+}
+
+// MarshalArgs returns a canonical argv that parses back to the current values of the
+// DefaultParamSet.
+func MarshalArgs() ([]string, error) {
+    return defaultParamSet.MarshalArgs()
+}
+
+// joinArgs joins the arguments of the specs, omitting the optional specs with default values if
+// omitDefaults is true, and returns whether parsing the result allocates the same arguments to
+// each spec
+func (ps *ParamSet) joinArgs(specArgs [][]string, omitDefaults bool) ([]string, bool) {
+    argv := []string {}
+    expected := make([]int, len(*ps))
+    for i, paramSpec := range *ps {
+        if omitDefaults && paramSpec.MinLength() == 0 && isDefault(paramSpec, specArgs[i]) {
+            continue
+        }
+        argv = append(argv, specArgs[i]...)
+        expected[i] = len(specArgs[i])
+    }
+    lengths, err := ps.allocate(argv, false, nil)
+    return argv, err == nil && reflect.DeepEqual(lengths, expected)
+}
+
+// MarshalValue returns the arguments that set the value, which can be a Value or a ValueReceiver.
+// If the value implements ArgsMarshaler, its MarshalArgs is used. Otherwise, if it implements
+// fmt.Stringer, the result of String is the only argument.
+func MarshalValue(value interface{}) ([]string, error) {
+    switch v := value.(type) {
+    case ArgsMarshaler:
+        return v.MarshalArgs()
+    case fmt.Stringer:
+        return []string { v.String() }, nil
+    }
+    return nil, fmt.Errorf("%T does not implement ArgsMarshaler or fmt.Stringer", value)
+}
+
+// marshalSpec returns the arguments of the ParamSpec
+func marshalSpec(paramSpec ParamSpec) ([]string, error) {
+    if marshaler, ok := paramSpec.(ArgsMarshaler); ok {
+        return marshaler.MarshalArgs()
+    }
+    return nil, fmt.Errorf("%T does not implement ArgsMarshaler", paramSpec)
+}
+
+// valueArgs returns the arguments of the whole value of the ParamSpec, including the defaults that
+// MarshalArgs omits
+func valueArgs(paramSpec ParamSpec) ([]string, error) {
+    if spec, ok := paramSpec.(interface{ valueArgs() ([]string, error) }); ok {
+        return spec.valueArgs()
+    }
+    return marshalSpec(paramSpec)
+}
+
+// isDefault returns whether args are the arguments of the ParamSpec when it was defined
+func isDefault(paramSpec ParamSpec, args []string) bool {
+    if spec, ok := paramSpec.(interface{ isDefault([]string) bool }); ok {
+        return spec.isDefault(args)
+    }
+    return false
+}
+
+// MarshalArgs returns the arguments of the value, see MarshalValue. Since Parse keeps the defaults
+// of a list and appends the arguments to them, the arguments of the defaults are omitted, and an
+// error is returned if the value does not start with the defaults.
+func (param *commonParamSpec) MarshalArgs() ([]string, error) {
+    args, err := MarshalValue(param.value)
+    if err != nil { return nil, err }
+    if _, ok := param.value.(valueContainer); ok {
+        // Set replaces the value of a Value, so there is nothing to omit
+        return args, nil
+    }
+    retained := param.retainedArgs
+    if retained == nil {
+        // Not parsed yet, so the defaults are those of a new process with the same definitions
+        retained = param.defaultArgs
+    }
+    if len(retained) > len(args) || !reflect.DeepEqual(args[:len(retained)], retained) {
+        return nil, fmt.Errorf("Values %s do not start with the defaults %s, which parsing would keep", quoteArgs(args), quoteArgs(retained))
+    }
+    return args[len(retained):], nil
+}
+
+func (param *commonParamSpec) valueArgs() ([]string, error) {
+    return MarshalValue(param.value)
+}
+
+func (param *commonParamSpec) snapshotArgs() {
+    param.defaultArgs, _ = MarshalValue(param.value)
+}
+
+func (param *commonParamSpec) isDefault(args []string) bool {
+    return param.defaultArgs != nil && reflect.DeepEqual(args, param.defaultArgs)
+}
+
+func (param *annotatedParamSpec) MarshalArgs() ([]string, error) {
+    return marshalSpec(param.ParamSpec)
+}
+
+func (param *annotatedParamSpec) valueArgs() ([]string, error) {
+    return valueArgs(param.ParamSpec)
+}
+
+func (param *annotatedParamSpec) snapshotArgs() {
+    if spec, ok := param.ParamSpec.(interface{ snapshotArgs() }); ok {
+        spec.snapshotArgs()
+    }
+}
+
+func (param *annotatedParamSpec) isDefault(args []string) bool {
+    return isDefault(param.ParamSpec, args)
+}
+
+// MarshalArgs returns the arguments of the Value, see MarshalValue.
+func (vc valueContainer) MarshalArgs() ([]string, error) {
+    return MarshalValue(vc.value)
+}
+
+// MarshalArgs returns the paths of the files.
+func (list *InputFileValueList) MarshalArgs() ([]string, error) {
+    args := []string {}
+    for _, f := range list.Files {
+        args = append(args, f.Path)
+    }
+    return args, nil
+}
+
+// MarshalArgs returns the times formatted with the Layout.
+func (list *TimeValueList) MarshalArgs() ([]string, error) {
+    args := []string {}
+    for _, t := range list.Values {
+        args = append(args, t.Format(list.Layout))
+    }
+    return args, nil
+}
+
+// MarshalArgs returns the expanded values. An error is returned if a value would be expanded again
+// as a glob pattern.
+func (list *GlobValueList) MarshalArgs() ([]string, error) {
+    return marshalGlobs(list.Values)
+}
+
+// MarshalArgs returns the arguments of the Receiver. An error is returned if an argument would be
+// expanded again as a glob pattern.
+func (g *GlobReceiver) MarshalArgs() ([]string, error) {
+    args, err := MarshalValue(g.Receiver)
+    if err != nil { return nil, err }
+    return marshalGlobs(args)
+}
+
+func marshalGlobs(args []string) ([]string, error) {
+    for _, arg := range args {
+        if IsGlobPattern(arg) {
+            return nil, fmt.Errorf(`"%s" would be expanded as a glob pattern`, arg)
+        }
+    }
+    return append([]string {}, args...), nil
+}
+
+// MarshalArgs returns the fields of each struct in the slice, in the order of the slots.
+func (list *GroupValueList) MarshalArgs() ([]string, error) {
+    args := []string {}
+    for i := 0; i < list.slice.Len(); i++ {
+        elem := list.slice.Index(i)
+        for s, fieldIndex := range list.fields {
+            value, err := fieldValue(elem.Field(fieldIndex))
+            if err != nil { return nil, err }
+            fieldArgs, err := MarshalValue(value)
+            if err == nil && len(fieldArgs) != 1 {
+                err = fmt.Errorf("Expected 1 argument, but got %d", len(fieldArgs))
+            }
+            if err != nil {
+                return nil, i18n.Errorf(i18n.GroupSlot, i + 1, list.slots[s], err)
+            }
+            args = append(args, fieldArgs[0])
+        }
+    }
+    return args, nil
+}
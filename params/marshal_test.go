@@ -0,0 +1,132 @@
+package params
+
+import (
+    "net/url"
+    "reflect"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestMarshalArgsRoundTrip(t *testing.T) {
+
+    // Test setup
+
+    type copyOp struct {
+        Src string
+        Dst string
+    }
+    define := func(ps *ParamSet) {
+        ps.Choice("mode", []string { "fast", "slow" }, true, nil)
+        var ops []copyOp
+        ps.GroupListCustom(&ops, "ops", 0, 2, nil)
+        ps.IntList("sizes", false, nil)
+        ps.Duration("timeout", true, nil)
+        ps.URL("endpoint", false, nil)
+        ps.StringMap("env", RejectDuplicates, true, nil)
+    }
+    cases := [][]string {
+        { "1", "2", "http://example.com/a?b=c" },
+        { "slow", "1", "http://x", "K=v", "A=b=c" },
+        { "fast", "a", "b", "c", "d", "7", "http://x" },
+    }
+    for _, argv := range cases {
+        p := NewSchema(define).NewParamSet()
+        if err := p.Parse(argv); err != nil { t.Fatalf("%q: error is not nil: %v", argv, err) }
+
+        // Test execution
+
+        args, err := p.MarshalArgs()
+
+        // Assertions
+
+        if err != nil { t.Errorf("%q: error is not nil: %v", argv, err) }
+        expected := argv
+        if argv[len(argv) - 1] == "A=b=c" { expected = []string { "slow", "1", "http://x", "A=b=c", "K=v" } }
+        if !reflect.DeepEqual(args, expected) { t.Errorf("Args should be %q, but were %q", expected, args) }
+    }
+}
+
+func TestMarshalArgsValues(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    endpoint := p.URL("endpoint", false, nil)
+    when := p.Time("when", time.RFC3339, false, nil)
+    optional := p.String("optional", true, nil)
+    var count int
+    p.VarValue(NewIntValue(3, &count), "count", true, nil)
+    rest := p.StringList("rest", true, nil)
+
+    // Test execution
+
+    u, _ := url.Parse("https://example.com/path?q=a+b")
+    *endpoint = *u
+    *when = time.Date(2020, 5, 17, 10, 30, 0, 0, time.UTC)
+    count = 5
+    *rest = []string { "-x", "" }
+    args, err := p.MarshalArgs()
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    // optional cannot be omitted, since count would be allocated to it
+    expected := []string { "https://example.com/path?q=a+b", "2020-05-17T10:30:00Z", *optional, "5", "-x", "" }
+    if !reflect.DeepEqual(args, expected) { t.Errorf("Args should be %q, but were %q", expected, args) }
+}
+
+func TestMarshalArgsListDefaults(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    pairs := []copyPair { { "a", "b", 1 } }
+    p.GroupListCustom(&pairs, "pairs", 0, -1, nil)
+    paths := &StringValueList{ "." }
+    p.VarList(paths, "paths", true, nil)
+    if err := p.Parse([]string { "c", "d", "2", "x" }); err != nil { t.Fatalf("Error is not nil: %v", err) }
+
+    // Test execution
+
+    args, err1 := p.MarshalArgs()
+    err2 := p.Parse(args)
+
+    // Assertions
+
+    if err1 != nil || err2 != nil { t.Errorf("Errors should be nil, but were %v, %v", err1, err2) }
+    if !reflect.DeepEqual(args, []string { "c", "d", "2", "x" }) { t.Errorf("Defaults should be omitted, but args were %q", args) }
+    if !reflect.DeepEqual(*paths, StringValueList { ".", "x" }) { t.Errorf(`paths should be ["." "x"], but was %q`, *paths) }
+    if !reflect.DeepEqual(pairs, []copyPair { { "a", "b", 1 }, { "c", "d", 2 } }) { t.Errorf("Unexpected pairs: %v", pairs) }
+
+    // Test execution
+
+    *paths = StringValueList { "y" }
+    _, err := p.MarshalArgs()
+
+    // Assertions
+
+    expected := `Cannot marshal paths: Values ["y"] do not start with the defaults ["."], which parsing would keep`
+    if err == nil || err.Error() != expected { t.Errorf("Error should be %q, but was %v", expected, err) }
+}
+
+func TestMarshalArgsErrors(t *testing.T) {
+    cases := map[string]func(ps *ParamSet) {
+        `Arguments ["a" "b"] would not be allocated to the same parameters`: func(ps *ParamSet) {
+            *ps.StringList("items", false, nil) = []string { "a" }
+            *ps.StringList("more", true, nil) = []string { "b" }
+        },
+        `Cannot marshal files: "*.txt" would be expanded as a glob pattern`: func(ps *ParamSet) {
+            ps.VarList(&GlobValueList{ Values: []string { "*.txt" } }, "files", false, nil)
+        },
+        `Cannot marshal env: Key "" cannot be written as KEY=VALUE`: func(ps *ParamSet) {
+            *ps.StringMap("env", RejectDuplicates, false, nil) = map[string]string { "": "d" }
+        },
+    }
+    for expected, define := range cases {
+        _, err := NewSchema(define).NewParamSet().MarshalArgs()
+        if err == nil || !strings.HasPrefix(err.Error(), expected) {
+            t.Errorf("Error should be %q, but was %v", expected, err)
+        }
+    }
+}
@@ -2,6 +2,10 @@ package params
 
 import (
     "fmt"
+    "io"
+    "reflect"
+
+    "github.com/mauricelam/gocmdln/i18n"
 )
 
 // Value interface similar to flag.Value
@@ -14,6 +18,12 @@ type ValueReceiver interface {
     Set([]string) error
 }
 
+// Getter is implemented by Values and ValueReceivers that can return the value they received, like
+// flag.Getter. For example, the Get of a StringValueList returns a []string.
+type Getter interface {
+    Get() interface{}
+}
+
 // A container for Value to implement ValueReceiver
 type valueContainer struct {
     value Value
@@ -21,11 +31,26 @@ type valueContainer struct {
 
 func (vc valueContainer) Set(vals []string) error {
     if len(vals) != 1 {
-        return fmt.Errorf("Cannot set multiple values %v", vals)
+        return validationErrorf(i18n.MultipleValues, vals)
     }
     return vc.value.Set(vals[0])
 }
 
+// Get returns the value of the Value if it is a Getter, or the Value itself otherwise.
+func (vc valueContainer) Get() interface{} {
+    if getter, ok := vc.value.(Getter); ok {
+        return getter.Get()
+    }
+    return vc.value
+}
+
+func (vc valueContainer) Close() error {
+    if closer, ok := vc.value.(io.Closer); ok {
+        return closer.Close()
+    }
+    return nil
+}
+
 // ParamSet represents a set of parameter specifications. Typically, most applications do not need
 // to use this, but can use the functions defined in this "params" package directly, which forwards
 // the methods to DefaultParamSet.
@@ -64,35 +89,87 @@ type ParamSpec interface {
     Set([]string) error
 
     // Metadata field to contain arbitrary data associated with this parameter. Typically this
-    // would contain the usage string and other help values, but it can contain any arbitrary value.
+    // is a Help, which contains the usage string and other help values, but it can contain any
+    // arbitrary value. See HelpOf and ExtraOf.
     Metadata() interface{}
 
     fmt.Stringer
 }
 
+// Committer can optionally be implemented by a ParamSpec or a Value whose value has side effects,
+// like creating a file. Parse calls Commit after the arguments of all the ParamSpecs were set
+// successfully, so that nothing is changed if the arguments are invalid.
+type Committer interface {
+    Commit() error
+}
+
+// Reserver can optionally be implemented by a ParamSpec that wants to claim arguments at the end of
+// argv, even if the variable length specs before it would otherwise capture them. For example, a
+// list of KEY=VALUE pairs after a list of files.
+type Reserver interface {
+
+    // ReserveLength is called before capturing with the longest slice of argv that can end with the
+    // arguments of this spec, and returns the number of arguments at the end of the slice that
+    // this spec wants to capture. These arguments will not be offered to the specs before it.
+    ReserveLength(argvSlice []string) int
+}
+
 type commonParamSpec struct {
     name string
     minLength int
     maxLength int
-    set func([]string) error
+    value ValueReceiver
     metadata interface{}
+    // defaults is a copy of the value before the first parse, see Reset
+    defaults reflect.Value
+    // defaultArgs is the arguments of the value when the spec was added to a ParamSet, see
+    // MarshalArgs
+    defaultArgs []string
+    // retainedArgs is the arguments of the value before the first reset, which are the defaults
+    // that the arguments of Set are added to, or nil if the spec was not reset yet
+    retainedArgs []string
 }
 
 var _ ParamSpec = (*commonParamSpec)(nil)
+var _ Resetter = (*commonParamSpec)(nil)
 
 func (param *commonParamSpec) MinLength() int {
     return param.minLength
 }
 
 func (param *commonParamSpec) CaptureLength(argvSlice []string) (int, error) {
-    if sliceLen := len(argvSlice); param.maxLength == -1 || param.maxLength > sliceLen {
+    if sliceLen := len(argvSlice); param.maxLength < 0 || param.maxLength > sliceLen {
         return sliceLen, nil
     }
     return param.maxLength, nil
 }
 
 func (param *commonParamSpec) Set(args []string) error {
-    return param.set(args)
+    return param.value.Set(args)
+}
+
+// Close closes the value if it implements io.Closer, e.g. an InputFileValue.
+func (param *commonParamSpec) Close() error {
+    if closer, ok := param.value.(io.Closer); ok {
+        return closer.Close()
+    }
+    return nil
+}
+
+// Commit commits the value if it implements Committer.
+func (param *commonParamSpec) Commit() error {
+    var target interface{} = param.value
+    if vc, ok := target.(valueContainer); ok {
+        target = vc.value
+    }
+    if committer, ok := target.(Committer); ok {
+        return committer.Commit()
+    }
+    return nil
+}
+
+func (param *commonParamSpec) receiver() ValueReceiver {
+    return param.value
 }
 
 func (param *commonParamSpec) String() string {
@@ -170,19 +247,23 @@ func VarListCustom(value ValueReceiver, name string, minLength int, maxLength in
 }
 
 // NewCustomParamSpec creates a parameter list spec using ValueReceiver that captures a list of the
-// specified min and max length from the remaining arguments.
+// specified min and max length from the remaining arguments. A negative max length means the list
+// is unbounded.
 func NewCustomParamSpec(value ValueReceiver, name string, minLength int, maxLength int, metadata interface{}) ParamSpec {
     return &commonParamSpec{
         name: name,
         minLength: minLength,
         maxLength: maxLength,
-        set: value.Set,
+        value: value,
         metadata: metadata,
     }
 }
 
 // Param adds a ParamSpec to the ParamSet
 func (ps *ParamSet) Param(paramSpec ParamSpec) {
+    if spec, ok := paramSpec.(interface{ snapshotArgs() }); ok {
+        spec.snapshotArgs()
+    }
     *ps = append(*ps, paramSpec)
 }
 
@@ -200,51 +281,194 @@ func Parse(argv []string) error {
 // Parse parses the given string list as the arguments, according to the ParamSpecs previously
 // added to the ParamSet.
 // See the documentation on ParamSpec for details on the parsing.
+//
+// If parsing fails, the ParamSpecs that have already been set and implement io.Closer are closed,
+// so that resources like open files are not leaked. The ParamSet can be parsed again, since the
+// values are reset to their defaults before parsing, see Reset.
 func (ps *ParamSet) Parse(argv []string) error {
+    _, err := ps.parse(argv, false, nil)
+    return err
+}
+
+// ParsePrefix parses the leading arguments of argv like Parse, but instead of failing when there
+// are too many arguments, it returns the number of arguments captured, leaving the rest to the
+// caller. For example, this is used for options whose arguments are described by a ParamSet.
+func (ps *ParamSet) ParsePrefix(argv []string) (int, error) {
+    return ps.parse(argv, true, nil)
+}
+
+// parse parses argv, recording the allocation in trace if it is not nil. If trace is nil and the
+// GOCMDLN_DEBUG environment variable is set, the trace is logged instead. Unless parsing a prefix,
+// the values are reset before parsing.
+func (ps *ParamSet) parse(argv []string, prefix bool, trace *Trace) (argvIndex int, err error) {
     if ps == nil {
         // No parameter set, just return
-        return nil
+        return 0, nil
+    }
+    if !prefix {
+        ps.Reset()
+    }
+    if trace == nil && debugEnabled() {
+        trace = new(Trace)
+        defer func() { warnf("%s", trace) }()
+    }
+    if trace != nil {
+        defer func() { trace.Err = err }()
+    }
+    lengths, err := ps.allocate(argv, prefix, trace)
+    if err != nil { return 0, err }
+
+    var setSpecs []ParamSpec
+    defer func() {
+        if err != nil {
+            closeSpecs(setSpecs)
+        }
+    }()
+    for i, paramSpec := range *ps {
+        l := lengths[i]
+        if l > 0 {
+            // Don't call Set if the slice is empty, to avoid initializing pointers when no values
+            // will be added. The spec is closed on failure even if Set fails, since it may have
+            // partially set its values.
+            setSpecs = append(setSpecs, paramSpec)
+            if deprecation := deprecationOf(paramSpec); deprecation != "" {
+                warnf("%s", i18n.Sprintf(i18n.DeprecatedArgument, paramSpec.String(), deprecation))
+            }
+            if err := paramSpec.Set(argv[argvIndex:argvIndex + l]); err != nil {
+                if trace != nil { trace.Steps[i].Err = err }
+                return argvIndex, toArgumentError(err, paramSpec.String(), argv[argvIndex:argvIndex + l])
+            }
+        }
+        argvIndex += l
+    }
+    return argvIndex, commitSpecs(setSpecs)
+}
+
+// commitSpecs commits the ParamSpecs that implement Committer
+func commitSpecs(paramSpecs []ParamSpec) error {
+    for _, paramSpec := range paramSpecs {
+        if committer, ok := paramSpec.(Committer); ok {
+            if err := committer.Commit(); err != nil {
+                return toArgumentError(err, paramSpec.String(), nil)
+            }
+        }
+    }
+    return nil
+}
+
+// allocate determines the number of arguments captured by each ParamSpec, without setting any
+// values. If trace is not nil, each step of the allocation is recorded in it.
+//
+// The ParamSpecs are allocated from left to right, and each one is offered all the arguments that
+// are not needed by the min lengths of the specs after it. In other words, among all the
+// allocations that satisfy the min and max lengths, the earlier specs have priority to capture as
+// many arguments as they want. If there are not enough arguments, the error names the first
+// required ParamSpec that cannot be filled when the arguments are assigned from the left.
+func (ps *ParamSet) allocate(argv []string, prefix bool, trace *Trace) ([]int, error) {
+    if trace != nil {
+        trace.Argv = argv
+        trace.Steps = make([]TraceStep, len(*ps))
     }
     // First pass determines the min length of all the arguments
     minLengths := make([]int, len(*ps))
     minArgCount := 0
+    missing := -1
     for i, paramSpec := range *ps {
         minLengths[i] = paramSpec.MinLength()
         minArgCount += minLengths[i]
+        if trace != nil {
+            trace.Steps[i] = TraceStep{ Spec: paramSpec.String(), MinLength: minLengths[i], Captured: -1 }
+        }
+        if missing < 0 && minArgCount > len(argv) {
+            missing = i
+        }
+    }
+    if missing >= 0 {
+        name := (*ps)[missing].String()
+        return nil, argumentError(ErrMissingArgument, name, i18n.MissingArgument, name)
     }
 
+    reserved := ps.reserveLengths(argv, minLengths, minArgCount)
+
     // Iterate over the arguments again to capture the variable length arguments
     // In this pass, the remaining arguments are allocated 
+    lengths := make([]int, len(*ps))
     remainingMinArg := minArgCount
     argvIndex := 0
     for i, paramSpec := range *ps {
         ml := minLengths[i]
-        sliceEnd := len(argv) - remainingMinArg + ml
+        sliceEnd := len(argv) - remainingMinArg + ml - reserved[i]
+        if trace != nil {
+            trace.Steps[i].Reserved = reserved[i]
+            if sliceEnd > argvIndex { trace.Steps[i].Offered = argv[argvIndex:sliceEnd] }
+        }
         if sliceEnd <= argvIndex {
             if ml > 0 {
                 // Argument is required but missing. Print error message and return.
-                return argumentErrorf(`Missing required argument "%s"`, paramSpec.String())
+                return nil, argumentError(ErrMissingArgument, paramSpec.String(), i18n.MissingArgument, paramSpec.String())
             }
             // No argument available for parsing, but this param is not required
             continue
         }
         l, err := paramSpec.CaptureLength(argv[argvIndex:sliceEnd])
-        if err != nil { return &ArgumentError{ err } }
-        if l < ml {
-            return argumentErrorf(`Argument "%s" captured less than min length`, paramSpec.String())
+        if trace != nil {
+            trace.Steps[i].Captured = l
+            trace.Steps[i].Err = err
         }
-        if l > 0 {
-            // Don't call Set if the slice is empty, to avoid initializing pointers when no values
-            // will be added
-            paramSpec.Set(argv[argvIndex:argvIndex + l])
+        if err != nil { return nil, toArgumentError(err, paramSpec.String(), argv[argvIndex:sliceEnd]) }
+        if l < ml {
+            return nil, argumentError(ErrValidation, paramSpec.String(), i18n.CapturedLessThanMin, paramSpec.String())
         }
+        lengths[i] = l
+        if trace != nil { trace.Steps[i].Assigned = argv[argvIndex:argvIndex + l] }
         argvIndex += l
         remainingMinArg -= ml
     }
 
-    if argvIndex < len(argv) {
-        return argumentErrorf(`Too many arguments. %d remaining`, len(argv) - argvIndex)
+    if !prefix && argvIndex < len(argv) {
+        remaining := len(argv) - argvIndex
+        return nil, &ArgumentError{
+            err: i18n.Nerrorf(i18n.TooManyArguments, remaining, remaining),
+            Kind: ErrTooManyArguments,
+            Args: argv[argvIndex:],
+        }
     }
 
-    return nil
+    return lengths, nil
+}
+
+// closeSpecs closes the ParamSpecs that implement io.Closer. Errors are ignored, since this is
+// only used to release resources after parsing already failed.
+func closeSpecs(paramSpecs []ParamSpec) {
+    for _, paramSpec := range paramSpecs {
+        if closer, ok := paramSpec.(io.Closer); ok {
+            closer.Close()
+        }
+    }
+}
+
+// reserveLengths returns, for each ParamSpec, the number of arguments at the end of argv that are
+// reserved by the Reservers after it, in addition to their min lengths. The reservations are made
+// from the last spec backwards, so each Reserver is only offered the arguments that the specs after
+// it do not need.
+func (ps *ParamSet) reserveLengths(argv []string, minLengths []int, minArgCount int) []int {
+    reserved := make([]int, len(*ps))
+    minAfter := 0
+    extraAfter := 0
+    for i := len(*ps) - 1; i >= 0; i-- {
+        reserved[i] = extraAfter
+        ml := minLengths[i]
+        if reserver, ok := (*ps)[i].(Reserver); ok {
+            end := len(argv) - minAfter - extraAfter
+            // The arguments needed by the specs before this one cannot be reserved
+            maxExtra := end - (minArgCount - minAfter - ml) - ml
+            if end > 0 && maxExtra > 0 {
+                extra := reserver.ReserveLength(argv[:end]) - ml
+                if extra > maxExtra { extra = maxExtra }
+                if extra > 0 { extraAfter += extra }
+            }
+        }
+        minAfter += ml
+    }
+    return reserved
 }
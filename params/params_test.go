@@ -132,3 +132,18 @@ func TestMetadata(t *testing.T) {
         t.Errorf(`Unexpected metadata "%v"`, metadata)
     }
 }
+
+func TestParsePrefix(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    arg1 := p.String("arg1", false, nil)
+    arg2 := p.String("arg2", true, nil)
+
+    // Execution
+    n, err := p.ParsePrefix([]string { "a", "b", "c" })
+
+    // Assertions
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    if n != 2 { t.Errorf("Should capture 2 arguments, but captured %d", n) }
+    if *arg1 != "a" || *arg2 != "b" { t.Errorf("Unexpected args: %s %s", *arg1, *arg2) }
+}
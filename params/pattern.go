@@ -0,0 +1,89 @@
+package params
+
+import (
+    "regexp"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+// patternParamSpec is a ParamSpec that only captures the leading arguments that satisfy the match
+// function, up to the max length.
+type patternParamSpec struct {
+    commonParamSpec
+    match func(string) bool
+}
+
+var _ ParamSpec = (*patternParamSpec)(nil)
+
+func (param *patternParamSpec) CaptureLength(argvSlice []string) (int, error) {
+    l := 0
+    for l < len(argvSlice) && (param.maxLength < 0 || l < param.maxLength) && param.match(argvSlice[l]) {
+        l++
+    }
+    if l < param.minLength {
+        if l < len(argvSlice) {
+            invalid := argumentError(ErrValidation, param.name, i18n.InvalidValue, argvSlice[l], param.name)
+            invalid.Args = argvSlice[l:l + 1]
+            return l, invalid
+        }
+        return l, argumentError(ErrMissingArgument, param.name, i18n.MissingArgument, param.name)
+    }
+    return l, nil
+}
+
+// NewPatternParamSpec creates a parameter list spec using ValueReceiver that captures the leading
+// arguments for which match returns true, with the specified min and max length. Arguments that
+// are reserved for required parameters after this one are never offered to match.
+func NewPatternParamSpec(value ValueReceiver, name string, match func(string) bool, minLength int, maxLength int, metadata interface{}) ParamSpec {
+    return &patternParamSpec{
+        commonParamSpec: commonParamSpec{
+            name: name,
+            minLength: minLength,
+            maxLength: maxLength,
+            value: value,
+            metadata: metadata,
+        },
+        match: match,
+    }
+}
+
+// VarMatching adds a parameter list spec using ValueReceiver that captures the leading arguments
+// for which match returns true, with the specified min and max length.
+func (ps *ParamSet) VarMatching(value ValueReceiver, name string, match func(string) bool, minLength int, maxLength int, metadata interface{}) {
+    ps.Param(NewPatternParamSpec(value, name, match, minLength, maxLength, metadata))
+}
+
+// VarMatching adds a parameter list spec using ValueReceiver that captures the leading arguments
+// for which match returns true, with the specified min and max length on the DefaultParamSet.
+func VarMatching(value ValueReceiver, name string, match func(string) bool, minLength int, maxLength int, metadata interface{}) {
+    defaultParamSet.VarMatching(value, name, match, minLength, maxLength, metadata)
+}
+
+// VarPattern adds a parameter list spec using ValueReceiver that captures the leading arguments
+// matching the regular expression. Note that the pattern is not anchored, so use ^ and $ to match
+// the whole argument.
+func (ps *ParamSet) VarPattern(value ValueReceiver, name string, pattern *regexp.Regexp, optional bool, metadata interface{}) {
+    minLength := 0
+    if !optional { minLength = 1 }
+    ps.VarMatching(value, name, pattern.MatchString, minLength, -1, metadata)
+}
+
+// VarPattern adds a parameter list spec using ValueReceiver that captures the leading arguments
+// matching the regular expression on the DefaultParamSet.
+func VarPattern(value ValueReceiver, name string, pattern *regexp.Regexp, optional bool, metadata interface{}) {
+    defaultParamSet.VarPattern(value, name, pattern, optional, metadata)
+}
+
+// StringPattern creates a parameter of type string that captures the leading arguments matching
+// the regular expression.
+func (ps *ParamSet) StringPattern(name string, pattern *regexp.Regexp, optional bool, metadata interface{}) *[]string {
+    list := new(StringValueList)
+    ps.VarPattern(list, name, pattern, optional, metadata)
+    return (*[]string)(list)
+}
+
+// StringPattern creates a parameter of type string that captures the leading arguments matching
+// the regular expression on the DefaultParamSet.
+func StringPattern(name string, pattern *regexp.Regexp, optional bool, metadata interface{}) *[]string {
+    return defaultParamSet.StringPattern(name, pattern, optional, metadata)
+}
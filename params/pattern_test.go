@@ -0,0 +1,83 @@
+package params
+
+import (
+    "reflect"
+    "regexp"
+    "testing"
+)
+
+func TestPatternParsing(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    goFiles := p.StringPattern("goFiles", regexp.MustCompile(`\.go$`), false, nil)
+    others := p.StringList("others", true, nil)
+
+    // Test execution
+
+    err := p.Parse([]string { "a.go", "b.go", "c.txt", "d.go" })
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if !reflect.DeepEqual(*goFiles, []string { "a.go", "b.go" }) {
+        t.Errorf(`goFiles should be ["a.go", "b.go"], but was %v`, *goFiles)
+    }
+    if !reflect.DeepEqual(*others, []string { "c.txt", "d.go" }) {
+        t.Errorf(`others should be ["c.txt", "d.go"], but was %v`, *others)
+    }
+}
+
+func TestPatternHonoursRequiredArgs(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    commits := p.StringPattern("commit", regexp.MustCompile(`^[0-9a-f]{7,40}$`), true, nil)
+    path := p.String("path", false, nil)
+
+    // Test execution
+
+    err := p.Parse([]string { "abcdef1", "1234567" })
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if !reflect.DeepEqual(*commits, []string { "abcdef1" }) {
+        t.Errorf(`commits should be ["abcdef1"], but was %v`, *commits)
+    }
+    if *path != "1234567" { t.Errorf(`path should be "1234567", but was %s`, *path) }
+}
+
+func TestPatternMaxLength(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    var list StringValueList
+    p.VarMatching(&list, "digits", regexp.MustCompile(`^\d+$`).MatchString, 0, 2, nil)
+    rest := p.StringList("rest", true, nil)
+
+    // Execution
+    err := p.Parse([]string { "1", "2", "3" })
+
+    // Assertions
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    if !reflect.DeepEqual([]string(list), []string { "1", "2" }) {
+        t.Errorf("Unexpected digits: %v", list)
+    }
+    if !reflect.DeepEqual(*rest, []string { "3" }) { t.Errorf("Unexpected rest: %v", *rest) }
+}
+
+func TestPatternRequiredMismatch(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    p.StringPattern("number", regexp.MustCompile(`^\d+$`), false, nil)
+
+    // Execution
+    err := p.Parse([]string { "abc" })
+
+    // Assertions
+    if err == nil || err.Error() != `Invalid value "abc" for argument "number"` {
+        t.Errorf("Unexpected error: %v", err)
+    }
+}
@@ -0,0 +1,187 @@
+package params
+
+import (
+    "encoding/json"
+    "fmt"
+    "io"
+    "os"
+    "reflect"
+)
+
+// Sources of the values of the parameters in a Recording
+const (
+    // SourceArgs is the source of a value that was set from the arguments
+    SourceArgs = "args"
+    // SourceProgram is the source of a value that was not set from the arguments, but is different
+    // from the value the parameter had when it was defined, because the program set it before
+    // parsing, e.g. from an environment variable or a configuration file
+    SourceProgram = "program"
+    // SourceDefault is the source of a value that is still the value the parameter had when it was
+    // defined
+    SourceDefault = "default"
+)
+
+// Recording is the fully resolved result of a parse, which can be saved as JSON and replayed
+// later with ParamSet.Replay to reproduce the same values, e.g. to debug a run of a data pipeline.
+type Recording struct {
+    // Argv is the arguments that were parsed. Recordings made by the options package include the
+    // options too.
+    Argv []string `json:"argv"`
+    // Options is the values of the options, in the order of the OptionSet, if the recording was
+    // made by the options package
+    Options []RecordedParam `json:"options,omitempty"`
+    // Params is the values of the parameters, in the order of the ParamSet
+    Params []RecordedParam `json:"params"`
+}
+
+// RecordedParam is the value of a parameter in a Recording.
+type RecordedParam struct {
+    // Name is the name of the ParamSpec, as returned by its String method
+    Name string `json:"name"`
+    // Source is SourceArgs, SourceProgram or SourceDefault
+    Source string `json:"source"`
+    // Args is the arguments assigned to the parameter if the source is SourceArgs. Otherwise it is
+    // the arguments that set the value, as returned by MarshalValue, or nil if the value cannot be
+    // marshaled.
+    Args []string `json:"args"`
+    // Value is the resolved value, formatted with its String method if it has one, or with %v
+    Value string `json:"value"`
+}
+
+// ParseRecord parses argv like Parse, and returns a Recording of the values of the parameters.
+func (ps *ParamSet) ParseRecord(argv []string) (*Recording, error) {
+    trace := new(Trace)
+    if _, err := ps.parse(argv, false, trace); err != nil { return nil, err }
+    recording := &Recording{ Argv: argv, Params: []RecordedParam {} }
+    for i, paramSpec := range *ps {
+        param := RecordedParam{ Name: paramSpec.String(), Source: SourceDefault, Value: formatValue(paramSpec) }
+        if assigned := trace.Steps[i].Assigned; len(assigned) > 0 {
+            param.Source, param.Args = SourceArgs, assigned
+        } else if args, err := valueArgs(paramSpec); err == nil {
+            param.Args = args
+            if !isDefault(paramSpec, args) { param.Source = SourceProgram }
+        }
+        recording.Params = append(recording.Params, param)
+    }
+    return recording, nil
+}
+
+// ParseRecord parses argv into the default ParamSet and returns a Recording of the values.
+func ParseRecord(argv []string) (*Recording, error) {
+    return defaultParamSet.ParseRecord(argv)
+}
+
+// Replay resets the values and sets them to the values in the recording. The parameters that were
+// set from the arguments are set from the same arguments, without allocating them again. The
+// parameters whose defaults are different from the recorded values, e.g. because the environment
+// changed, are cleared and set from the recorded Args. An error is returned if the recording does
+// not have the same parameters as the ParamSet, or if a value cannot be reproduced.
+func (ps *ParamSet) Replay(recording *Recording) error {
+    if len(recording.Params) != len(*ps) {
+        return fmt.Errorf("Recording has %d parameters, but %d were expected", len(recording.Params), len(*ps))
+    }
+    ps.Reset()
+    var setSpecs []ParamSpec
+    for i, paramSpec := range *ps {
+        param := recording.Params[i]
+        name := paramSpec.String()
+        if param.Name != name {
+            closeSpecs(setSpecs)
+            return fmt.Errorf("Recorded parameter %s does not match parameter %s", param.Name, name)
+        }
+        switch {
+        case param.Source == SourceArgs:
+            setSpecs = append(setSpecs, paramSpec)
+            if err := paramSpec.Set(param.Args); err != nil {
+                closeSpecs(setSpecs)
+                return toArgumentError(err, name, param.Args)
+            }
+        case param.Source == SourceProgram || param.Source == SourceDefault:
+            set, err := restoreSpec(paramSpec, param)
+            if set { setSpecs = append(setSpecs, paramSpec) }
+            if err != nil {
+                closeSpecs(setSpecs)
+                return fmt.Errorf("Cannot restore the recorded value %q of %s: %w", param.Value, name, err)
+            }
+        default:
+            closeSpecs(setSpecs)
+            return fmt.Errorf("Unknown source %q of recorded parameter %s", param.Source, name)
+        }
+    }
+    if err := commitSpecs(setSpecs); err != nil {
+        closeSpecs(setSpecs)
+        return err
+    }
+    return nil
+}
+
+// Replay resets the values of the default ParamSet and sets them to the values in the recording.
+func Replay(recording *Recording) error {
+    return defaultParamSet.Replay(recording)
+}
+
+// Write writes the recording to w as indented JSON.
+func (r *Recording) Write(w io.Writer) error {
+    encoder := json.NewEncoder(w)
+    encoder.SetIndent("", "  ")
+    return encoder.Encode(r)
+}
+
+// WriteFile writes the recording to the file with the name, creating or truncating it.
+func (r *Recording) WriteFile(name string) error {
+    f, err := os.Create(name)
+    if err != nil { return err }
+    if err := r.Write(f); err != nil {
+        f.Close()
+        return err
+    }
+    return f.Close()
+}
+
+// ReadRecording reads a Recording written by Recording.Write from r.
+func ReadRecording(r io.Reader) (*Recording, error) {
+    recording := new(Recording)
+    if err := json.NewDecoder(r).Decode(recording); err != nil { return nil, err }
+    return recording, nil
+}
+
+// ReadRecordingFile reads a Recording from the file with the name.
+func ReadRecordingFile(name string) (*Recording, error) {
+    f, err := os.Open(name)
+    if err != nil { return nil, err }
+    defer f.Close()
+    return ReadRecording(f)
+}
+
+// restoreSpec sets the ParamSpec to the recorded Args, if its current value has different
+// arguments, and returns whether it was set
+func restoreSpec(paramSpec ParamSpec, param RecordedParam) (bool, error) {
+    args, err := valueArgs(paramSpec)
+    if err != nil || param.Args == nil {
+        // The value cannot be set from arguments, so it can only be compared
+        if formatValue(paramSpec) == param.Value {
+            return false, nil
+        }
+        if err == nil { err = fmt.Errorf("No arguments were recorded") }
+        return false, err
+    }
+    if reflect.DeepEqual(args, param.Args) {
+        return false, nil
+    }
+    if resetter, ok := paramSpec.(Resetter); ok {
+        resetter.Reset(ClearValues)
+    }
+    if len(param.Args) == 0 {
+        return false, nil
+    }
+    return true, paramSpec.Set(param.Args)
+}
+
+// formatValue formats the value of the ParamSpec for a Recording
+func formatValue(paramSpec ParamSpec) string {
+    value, _ := valueOf(paramSpec)
+    if stringer, ok := value.(fmt.Stringer); ok {
+        return stringer.String()
+    }
+    return fmt.Sprintf("%v", value)
+}
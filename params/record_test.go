@@ -0,0 +1,125 @@
+package params
+
+import (
+    "bytes"
+    "reflect"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    sources := p.StringList("sources", false, nil)
+    dest := p.String("dest", false, nil)
+    var timeout time.Duration
+    p.VarValue(NewDurationValue(time.Second, &timeout), "timeout", true, nil)
+    tags := &StringValueList{ "x" }
+    p.VarList(tags, "tags", true, nil)
+    // The program adds a tag before parsing, e.g. from the environment
+    *tags = append(*tags, "env")
+
+    // Test execution
+
+    recording, err := p.ParseRecord([]string { "a", "b", "out" })
+    if err != nil { t.Fatalf("Error is not nil: %v", err) }
+    var buffer bytes.Buffer
+    recording.Write(&buffer)
+    replayed, err := ReadRecording(strings.NewReader(buffer.String()))
+    if err != nil { t.Fatalf("Error is not nil: %v", err) }
+    p.Parse([]string { "x", "y" })
+    // The defaults changed since the recording, e.g. because they come from the environment
+    timeout = time.Minute
+    *tags = StringValueList { "y" }
+    p.Reset()
+    err = p.Replay(replayed)
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    expected := []RecordedParam {
+        { Name: "sources", Source: SourceArgs, Args: []string { "a", "b" }, Value: "[a b]" },
+        { Name: "dest", Source: SourceArgs, Args: []string { "out" }, Value: "out" },
+        { Name: "timeout", Source: SourceDefault, Args: []string { "1s" }, Value: "1s" },
+        { Name: "tags", Source: SourceProgram, Args: []string { "x", "env" }, Value: "[x env]" },
+    }
+    if !reflect.DeepEqual(replayed.Params, expected) { t.Errorf("Unexpected recording: %+v", replayed.Params) }
+    if !reflect.DeepEqual(replayed.Argv, []string { "a", "b", "out" }) { t.Errorf("Unexpected argv: %q", replayed.Argv) }
+    if !reflect.DeepEqual(*sources, []string { "a", "b" }) || *dest != "out" || timeout != time.Second {
+        t.Errorf("Unexpected values after replay: %q %q %v", *sources, *dest, timeout)
+    }
+    if !reflect.DeepEqual(*tags, StringValueList { "x", "env" }) { t.Errorf(`tags should be replayed as ["x" "env"], but were %q`, *tags) }
+}
+
+func TestReplayRestoresListsMapsAndGroups(t *testing.T) {
+
+    // Test setup
+
+    // newParamSet creates the parameters with the defaults of the program, e.g. from the
+    // environment, which differ between the recorded run and the replay
+    newParamSet := func(home string, pairs *[]copyPair) (*ParamSet, *map[string]string) {
+        p := new(ParamSet)
+        env := p.StringMap("env", RejectDuplicates, true, nil)
+        *env = map[string]string { "HOME": home }
+        p.GroupListCustom(pairs, "pairs", 0, -1, nil)
+        return p, env
+    }
+    recordedPairs := []copyPair { { "a", "b", 1 } }
+    recorded, _ := newParamSet("/home/a", &recordedPairs)
+    var pairs []copyPair
+    p, env := newParamSet("/home/b", &pairs)
+
+    // Test execution
+
+    recording, err1 := recorded.ParseRecord([]string {})
+    err2 := p.Replay(recording)
+
+    // Assertions
+
+    if err1 != nil || err2 != nil { t.Errorf("Errors should be nil, but were %v, %v", err1, err2) }
+    if source := recording.Params[0].Source; source != SourceProgram { t.Errorf("env should be set by the program, but was %s", source) }
+    if !reflect.DeepEqual(*env, map[string]string { "HOME": "/home/a" }) { t.Errorf("Unexpected env: %v", *env) }
+    if !reflect.DeepEqual(pairs, []copyPair { { "a", "b", 1 } }) { t.Errorf("Unexpected pairs: %v", pairs) }
+}
+
+func TestReplayErrors(t *testing.T) {
+    p := new(ParamSet)
+    p.Int("count", false, nil)
+    p.StringList("files", true, nil)
+
+    cases := map[string]*Recording {
+        "Recording has 1 parameters, but 2 were expected": { Params: []RecordedParam { { Name: "count", Source: SourceArgs, Args: []string { "1" } } } },
+        "Recorded parameter number does not match parameter count": { Params: []RecordedParam {
+            { Name: "number", Source: SourceArgs, Args: []string { "1" } },
+            { Name: "files", Source: SourceDefault, Value: "[]" },
+        } },
+        `Cannot restore the recorded value "[a]" of files: No arguments were recorded`: { Params: []RecordedParam {
+            { Name: "count", Source: SourceArgs, Args: []string { "1" } },
+            { Name: "files", Source: SourceDefault, Value: "[a]" },
+        } },
+        `Cannot restore the recorded value "x" of count: strconv.ParseInt: parsing "x": invalid syntax`: { Params: []RecordedParam {
+            { Name: "count", Source: SourceProgram, Args: []string { "x" }, Value: "x" },
+            { Name: "files", Source: SourceDefault, Args: []string {}, Value: "[]" },
+        } },
+        `Unknown source "config" of recorded parameter files`: { Params: []RecordedParam {
+            { Name: "count", Source: SourceArgs, Args: []string { "1" } },
+            { Name: "files", Source: "config", Value: "[]" },
+        } },
+    }
+    for expected, recording := range cases {
+        if err := p.Replay(recording); err == nil || err.Error() != expected {
+            t.Errorf("Error should be %q, but was %v", expected, err)
+        }
+    }
+
+    recording := &Recording{ Params: []RecordedParam {
+        { Name: "count", Source: SourceArgs, Args: []string { "many" } },
+        { Name: "files", Source: SourceDefault, Value: "[]" },
+    } }
+    if err := p.Replay(recording); ExitCode(err) != 2 || !strings.Contains(err.Error(), "many") {
+        t.Errorf("Conversion error should be an ArgumentError, but was %v", err)
+    }
+}
@@ -0,0 +1,135 @@
+package params
+
+import (
+    "io"
+    "reflect"
+)
+
+// ResetPolicy specifies the values that a ParamSpec is reset to.
+type ResetPolicy int
+
+const (
+    // RetainDefaults resets the values to the defaults they had before the first parse, e.g. the
+    // val passed to NewStringValue
+    RetainDefaults ResetPolicy = iota
+    // ClearValues resets the values to their zero values, e.g. an empty list. For values that are
+    // structs, only the lists and maps are cleared, so settings like the Policy of a StringValueMap
+    // are kept.
+    ClearValues
+)
+
+// Resetter can be implemented by a ParamSpec or a ValueReceiver to define how it is reset before
+// the ParamSet is parsed again. Values that do not implement Resetter are reset by restoring a copy
+// of the value they pointed to before the first parse.
+type Resetter interface {
+    Reset(policy ResetPolicy)
+}
+
+// Reset resets the values of all the ParamSpecs to their defaults, so that the values received by
+// a previous parse do not accumulate, for example in a StringList. Values that implement io.Closer,
+// like InputFileValue, are closed first. Parse calls Reset before parsing, but ParsePrefix does not,
+// so that the arguments of a repeated option can accumulate.
+func (ps *ParamSet) Reset() {
+    ps.ResetWith(RetainDefaults)
+}
+
+// Reset resets the values of all the ParamSpecs of the DefaultParamSet to their defaults.
+func Reset() {
+    defaultParamSet.Reset()
+}
+
+// ResetWith resets the values of all the ParamSpecs according to the policy. ParamSpecs that do
+// not implement Resetter are left as they are.
+func (ps *ParamSet) ResetWith(policy ResetPolicy) {
+    if ps == nil {
+        return
+    }
+    for _, paramSpec := range *ps {
+        if resetter, ok := paramSpec.(Resetter); ok {
+            resetter.Reset(policy)
+        }
+    }
+}
+
+// Reset closes the value if it implements io.Closer, and then resets it according to the policy.
+// The defaults are snapshotted the first time the spec is reset, which Parse does before setting
+// any values.
+func (param *commonParamSpec) Reset(policy ResetPolicy) {
+    var target interface{} = param.value
+    if vc, ok := target.(valueContainer); ok {
+        target = vc.value
+    }
+    if param.retainedArgs == nil {
+        param.retainedArgs, _ = MarshalValue(param.value)
+    }
+    resetValue(target, &param.defaults, policy)
+}
+
+// resetValue closes the target if it implements io.Closer, and resets it with its Reset method if
+// it implements Resetter. Otherwise the target is restored from the snapshot in defaults, which is
+// taken the first time resetValue is called. Values that wrap a ValueReceiver use this to reset
+// the wrapped receiver too.
+func resetValue(target interface{}, defaults *reflect.Value, policy ResetPolicy) {
+    if closer, ok := target.(io.Closer); ok {
+        closer.Close()
+    }
+    if resetter, ok := target.(Resetter); ok {
+        resetter.Reset(policy)
+        return
+    }
+    ptr := reflect.ValueOf(target)
+    if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+        // The value cannot be restored
+        return
+    }
+    if !defaults.IsValid() {
+        *defaults = cloneValue(ptr.Elem())
+    }
+    value := cloneValue(*defaults)
+    if policy == ClearValues {
+        clearValue(value)
+    }
+    ptr.Elem().Set(value)
+}
+
+// clearValue sets v to its zero value, or if v is a struct, its exported slice and map fields
+func clearValue(v reflect.Value) {
+    if v.Kind() != reflect.Struct {
+        v.Set(reflect.Zero(v.Type()))
+        return
+    }
+    for i := 0; i < v.NumField(); i++ {
+        field := v.Field(i)
+        if field.CanSet() && (field.Kind() == reflect.Slice || field.Kind() == reflect.Map) {
+            field.Set(reflect.Zero(field.Type()))
+        }
+    }
+}
+
+// cloneValue returns a copy of v that does not share the slices and maps of v, including those in
+// the exported fields of a struct, so that values appended to the copy do not modify v.
+func cloneValue(v reflect.Value) reflect.Value {
+    clone := reflect.New(v.Type()).Elem()
+    clone.Set(v)
+    switch v.Kind() {
+    case reflect.Slice:
+        if !v.IsNil() {
+            clone.Set(reflect.AppendSlice(reflect.MakeSlice(v.Type(), 0, v.Len()), v))
+        }
+    case reflect.Map:
+        if !v.IsNil() {
+            m := reflect.MakeMapWithSize(v.Type(), v.Len())
+            for _, key := range v.MapKeys() {
+                m.SetMapIndex(key, v.MapIndex(key))
+            }
+            clone.Set(m)
+        }
+    case reflect.Struct:
+        for i := 0; i < v.NumField(); i++ {
+            if clone.Field(i).CanSet() {
+                clone.Field(i).Set(cloneValue(v.Field(i)))
+            }
+        }
+    }
+    return clone
+}
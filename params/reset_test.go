@@ -0,0 +1,236 @@
+package params
+
+import (
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "reflect"
+    "regexp"
+    "testing"
+)
+
+func TestRepeatedParse(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    files := p.StringList("files", false, nil)
+    env := p.StringMap("env", RejectDuplicates, true, nil)
+    var pairs []copyPair
+    p.GroupListCustom(&pairs, "pairs", 0, 1, nil)
+    mode := p.Choice("mode", []string { "fast", "slow" }, true, nil)
+
+    // Test execution
+
+    if err := p.Parse([]string { "a", "b", "x", "y", "1", "fast", "k=1" }); err != nil {
+        t.Fatalf("Error is not nil: %v", err)
+    }
+    err := p.Parse([]string { "c", "k=2" })
+
+    // Assertions
+
+    if err != nil { t.Errorf("Duplicate key from a previous parse should not fail: %v", err) }
+    if !reflect.DeepEqual(*files, []string { "c" }) { t.Errorf(`files should be ["c"], but was %q`, *files) }
+    if !reflect.DeepEqual(*env, map[string]string { "k": "2" }) { t.Errorf("Unexpected env: %v", *env) }
+    if len(pairs) != 0 { t.Errorf("pairs should be empty, but was %v", pairs) }
+    if *mode != "" { t.Errorf(`mode should be reset to "", but was %q`, *mode) }
+}
+
+func TestResetRetainsDefaults(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    var count int
+    p.VarValue(NewIntValue(3, &count), "count", true, nil)
+    paths := &StringValueList{ "." }
+    p.VarList(paths, "paths", true, nil)
+
+    // Test execution
+
+    err := p.Parse([]string { "5", "a", "b" })
+    p.Reset()
+
+    // Assertions
+
+    if err != nil { t.Errorf("Error is not nil: %v", err) }
+    if count != 3 { t.Errorf("count should be reset to 3, but was %d", count) }
+    if !reflect.DeepEqual(*paths, StringValueList { "." }) { t.Errorf(`paths should be ["."], but was %q`, *paths) }
+
+    // Test execution
+
+    p.ResetWith(ClearValues)
+
+    // Assertions
+
+    if count != 0 { t.Errorf("count should be cleared, but was %d", count) }
+    if len(*paths) != 0 { t.Errorf("paths should be cleared, but was %q", *paths) }
+}
+
+func TestResetDoesNotModifyPreviousValues(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    paths := &StringValueList{ "." }
+    p.VarList(paths, "paths", true, nil)
+
+    // Test execution
+
+    p.Parse([]string { "a" })
+    first := *paths
+    p.Parse([]string { "b" })
+
+    // Assertions
+
+    if !reflect.DeepEqual(first, StringValueList { ".", "a" }) { t.Errorf(`First values should be ["." "a"], but was %q`, first) }
+    if !reflect.DeepEqual(*paths, StringValueList { ".", "b" }) { t.Errorf(`paths should be ["." "b"], but was %q`, *paths) }
+}
+
+func TestParsePrefixAccumulates(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    values := p.StringListCustom("values", 1, 1, nil)
+
+    // Test execution
+
+    p.ParsePrefix([]string { "a" })
+    p.ParsePrefix([]string { "b" })
+
+    // Assertions
+
+    if !reflect.DeepEqual(*values, []string { "a", "b" }) { t.Errorf(`values should be ["a" "b"], but was %q`, *values) }
+}
+
+func TestResetAnnotatedSpec(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    list := new(StringValueList)
+    p.Param(Hidden(NewListParamSpec(list, "list", true, nil)))
+
+    // Test execution
+
+    p.Parse([]string { "a" })
+    p.Parse([]string { "b" })
+
+    // Assertions
+
+    if !reflect.DeepEqual(*list, StringValueList { "b" }) { t.Errorf(`list should be ["b"], but was %q`, *list) }
+}
+
+func TestRepeatedParseWrappers(t *testing.T) {
+    cases := map[string]struct {
+        // setup defines the parameter and returns a function that returns its value
+        setup func(p *ParamSet) func() interface{}
+        argv1, argv2 []string
+        want interface{}
+    }{
+        "glob receiver": {
+            func(p *ParamSet) func() interface{} {
+                list := new(StringValueList)
+                p.VarListGlob(list, "list", KeepUnmatched, false, nil)
+                return func() interface{} { return *list }
+            },
+            []string { "a" }, []string { "b" }, StringValueList { "b" },
+        },
+        "glob receiver with resetter": {
+            func(p *ParamSet) func() interface{} {
+                var pairs []copyPair
+                p.VarListGlob(NewGroupValueList(&pairs), "pairs", KeepUnmatched, false, nil)
+                return func() interface{} { return pairs }
+            },
+            []string { "a", "b", "1" }, []string { "c", "d", "2" }, []copyPair { { "c", "d", 2 } },
+        },
+        "glob value list": {
+            func(p *ParamSet) func() interface{} {
+                list := p.StringListGlob("list", KeepUnmatched, false, nil)
+                return func() interface{} { return list.Values }
+            },
+            []string { "a" }, []string { "b" }, []string { "b" },
+        },
+        "annotated": {
+            func(p *ParamSet) func() interface{} {
+                list := new(StringValueList)
+                p.Param(Renamed(Hidden(NewListParamSpec(list, "list", true, nil)), "old"))
+                return func() interface{} { return *list }
+            },
+            []string { "a" }, []string { "b" }, StringValueList { "b" },
+        },
+        "pattern": {
+            func(p *ParamSet) func() interface{} {
+                list := p.StringPattern("list", regexp.MustCompile(`^\w$`), false, nil)
+                return func() interface{} { return *list }
+            },
+            []string { "a" }, []string { "b" }, []string { "b" },
+        },
+        "map": {
+            func(p *ParamSet) func() interface{} {
+                env := p.StringMap("env", RejectDuplicates, false, nil)
+                return func() interface{} { return *env }
+            },
+            []string { "k=1" }, []string { "k=2" }, map[string]string { "k": "2" },
+        },
+        "group": {
+            func(p *ParamSet) func() interface{} {
+                var pairs []copyPair
+                p.GroupList(&pairs, "pairs", false, nil)
+                return func() interface{} { return pairs }
+            },
+            []string { "a", "b", "1" }, []string { "c", "d", "2" }, []copyPair { { "c", "d", 2 } },
+        },
+        "value container": {
+            func(p *ParamSet) func() interface{} {
+                var count int
+                p.VarValue(NewIntValue(7, &count), "count", true, nil)
+                return func() interface{} { return count }
+            },
+            []string { "5" }, []string {}, 7,
+        },
+    }
+    for name, c := range cases {
+
+        // Test setup
+
+        p := new(ParamSet)
+        value := c.setup(p)
+
+        // Test execution
+
+        err1 := p.Parse(c.argv1)
+        err2 := p.Parse(c.argv2)
+
+        // Assertions
+
+        if err1 != nil || err2 != nil { t.Errorf("%s: errors should be nil, but were %v, %v", name, err1, err2) }
+        if got := value(); !reflect.DeepEqual(got, c.want) { t.Errorf("%s: value should be %v, but was %v", name, c.want, got) }
+    }
+}
+
+func TestResetGlobReceiverExpansions(t *testing.T) {
+
+    // Test setup
+
+    dir := tempDir(t)
+    defer os.RemoveAll(dir)
+    ioutil.WriteFile(filepath.Join(dir, "a.txt"), nil, 0600)
+    p := new(ParamSet)
+    list := &StringValueList{ "." }
+    glob := p.VarListGlob(list, "list", KeepUnmatched, true, nil)
+
+    // Test execution
+
+    err1 := p.Parse([]string { filepath.Join(dir, "*.txt") })
+    expansions := len(glob.Expansions)
+    err2 := p.Parse([]string { "b" })
+
+    // Assertions
+
+    if err1 != nil || err2 != nil { t.Errorf("Errors should be nil, but were %v, %v", err1, err2) }
+    if expansions != 1 { t.Errorf("The pattern should be expanded, but expansions were %v", expansions) }
+    if len(glob.Expansions) != 0 { t.Errorf("Expansions should be cleared, but were %v", glob.Expansions) }
+    if !reflect.DeepEqual(*list, StringValueList { ".", "b" }) { t.Errorf(`list should be ["." "b"], but was %q`, *list) }
+}
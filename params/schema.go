@@ -0,0 +1,126 @@
+package params
+
+// Schema is an immutable definition of positional parameters that can be parsed any number of
+// times, including concurrently, for example by a server that parses command strings from many
+// clients. Unlike a ParamSet, whose values are created once when the parameters are defined, a
+// Schema creates new values for every parse and returns them in a Result.
+//
+// A Schema is defined by a function that adds the parameters to a ParamSet, using the same
+// methods as the pointer API:
+//
+//   schema := params.NewSchema(func(ps *params.ParamSet) {
+//       ps.StringList("sources", false, nil)
+//       ps.String("dest", false, nil)
+//   })
+//   result, err := schema.Parse(argv)
+//   sources := result.Get("sources").([]string)
+type Schema struct {
+    define func(ps *ParamSet)
+}
+
+// NewSchema creates a Schema whose parameters are added to a ParamSet by define. define is called
+// for every parse, possibly from several goroutines at once, so it must create new values every
+// time and must not have other side effects. In particular, the Values passed to VarValue or Var
+// must be created inside define, since a Value captured from outside the function would be shared
+// by all the parses:
+//
+//   schema := params.NewSchema(func(ps *params.ParamSet) {
+//       var level string
+//       ps.VarValue(params.NewChoiceValue("info", &level, levels), "level", true, nil)
+//   })
+//
+// Apart from define, the only state shared by the parses is the Logger that receives the warnings
+// of deprecated parameters. The default logger is safe for concurrent use; a Logger set with
+// SetLogger must be too, and SetLogger must not be called while a parse is running.
+func NewSchema(define func(ps *ParamSet)) *Schema {
+    return &Schema{ define }
+}
+
+// NewParamSet returns a new ParamSet with the parameters of the schema and new values. This can be
+// used to parse with the pointer API or the ParamSet methods, like ParseTrace or PrintDefaults,
+// without sharing the values with other parses.
+func (s *Schema) NewParamSet() *ParamSet {
+    ps := new(ParamSet)
+    s.define(ps)
+    return ps
+}
+
+// Synopsis returns the synopsis of the parameters of the schema, e.g. "<sources>... <dest>".
+func (s *Schema) Synopsis() string {
+    return s.NewParamSet().Synopsis()
+}
+
+// Parse parses argv into new values, using the same allocation as ParamSet.Parse, and returns the
+// values in a Result.
+func (s *Schema) Parse(argv []string) (*Result, error) {
+    ps := s.NewParamSet()
+    trace := new(Trace)
+    _, err := ps.parse(argv, false, trace)
+    if debugEnabled() {
+        warnf("%s", trace)
+    }
+    if err != nil { return nil, err }
+    result := &Result{ params: *ps, args: make(map[string][]string) }
+    for i, paramSpec := range *ps {
+        result.args[paramSpec.String()] = trace.Steps[i].Assigned
+    }
+    return result, nil
+}
+
+// Result is the values of a single parse of a Schema.
+type Result struct {
+    params ParamSet
+    args map[string][]string
+}
+
+// Lookup returns the value of the parameter with the name, and whether the schema has the
+// parameter. The value is the result of Get if the value implements Getter, for example an int
+// for an Int parameter or a []string for a StringList parameter, or the ValueReceiver otherwise.
+func (r *Result) Lookup(name string) (interface{}, bool) {
+    for _, paramSpec := range r.params {
+        if paramSpec.String() == name {
+            return valueOf(paramSpec)
+        }
+    }
+    return nil, false
+}
+
+// Get returns the value of the parameter with the name like Lookup, or nil if there is no such
+// parameter.
+func (r *Result) Get(name string) interface{} {
+    value, _ := r.Lookup(name)
+    return value
+}
+
+// Args returns the arguments assigned to the parameter with the name, or nil if it did not
+// receive any arguments.
+func (r *Result) Args(name string) []string {
+    return r.args[name]
+}
+
+// ParamSet returns the ParamSet that the values were parsed into, e.g. to print the help of the
+// parameters. The ParamSet belongs to this Result and is not used by other parses.
+func (r *Result) ParamSet() *ParamSet {
+    return &r.params
+}
+
+// valueOf returns the value received by the ParamSpec, unwrapping annotated specs. If the value
+// implements Getter, the result of Get is returned.
+func valueOf(paramSpec ParamSpec) (interface{}, bool) {
+    for {
+        switch spec := paramSpec.(type) {
+        case Getter:
+            return spec.Get(), true
+        case interface{ receiver() ValueReceiver }:
+            value := spec.receiver()
+            if getter, ok := value.(Getter); ok {
+                return getter.Get(), true
+            }
+            return value, true
+        case interface{ Unwrap() ParamSpec }:
+            paramSpec = spec.Unwrap()
+        default:
+            return nil, false
+        }
+    }
+}
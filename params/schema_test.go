@@ -0,0 +1,202 @@
+package params
+
+import (
+    "fmt"
+    "reflect"
+    "sync"
+    "testing"
+)
+
+func newCopySchema() *Schema {
+    return NewSchema(func(ps *ParamSet) {
+        ps.StringList("sources", false, nil)
+        ps.String("dest", false, nil)
+        ps.Param(Deprecated(NewParamSpec(new(StringValueList), "mode", true, nil), "Use --mode"))
+    })
+}
+
+func TestSchemaParse(t *testing.T) {
+
+    // Test setup
+
+    schema := newCopySchema()
+
+    // Test execution
+
+    first, err1 := schema.Parse([]string { "a", "b", "c" })
+    second, err2 := schema.Parse([]string { "d", "e" })
+
+    // Assertions
+
+    if err1 != nil || err2 != nil { t.Fatalf("Errors should be nil, but were %v, %v", err1, err2) }
+    if sources := first.Get("sources"); !reflect.DeepEqual(sources, []string { "a", "b" }) {
+        t.Errorf(`sources should be ["a" "b"], but was %#v`, sources)
+    }
+    if sources := second.Get("sources"); !reflect.DeepEqual(sources, []string { "d" }) {
+        t.Errorf(`Second parse should not append to the first, but sources was %#v`, sources)
+    }
+    if dest := second.Get("dest"); dest != "e" { t.Errorf(`dest should be "e", but was %#v`, dest) }
+    if args := first.Args("sources"); !reflect.DeepEqual(args, []string { "a", "b" }) {
+        t.Errorf(`Args of sources should be ["a" "b"], but was %q`, args)
+    }
+    if _, ok := first.Lookup("mode"); !ok { t.Errorf("mode should be found through the annotation") }
+    if _, ok := first.Lookup("unknown"); ok { t.Errorf("unknown should not be found") }
+    if first.ParamSet().Synopsis() != schema.Synopsis() {
+        t.Errorf("Synopsis should be %q, but was %q", schema.Synopsis(), first.ParamSet().Synopsis())
+    }
+}
+
+func TestSchemaParseError(t *testing.T) {
+
+    // Test setup
+
+    schema := newCopySchema()
+
+    // Test execution
+
+    result, err := schema.Parse([]string { "a" })
+
+    // Assertions
+
+    if result != nil { t.Errorf("Result should be nil on error") }
+    if err == nil || err.Error() != `Missing required argument "dest"` {
+        t.Errorf("Unexpected error: %v", err)
+    }
+}
+
+func TestSchemaConcurrentParseVarValue(t *testing.T) {
+
+    // Test setup
+
+    levels := []string { "debug", "info", "warn" }
+    schema := NewSchema(func(ps *ParamSet) {
+        var level string
+        var count int
+        ps.VarValue(NewChoiceValue("info", &level, levels), "level", true, nil)
+        ps.VarValue(NewIntValue(0, &count), "count", false, nil)
+    })
+
+    // Test execution
+
+    var wg sync.WaitGroup
+    errs := make(chan error, 30)
+    for i := 0; i < 30; i++ {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+            argv := []string { fmt.Sprint(i) }
+            level := "info"
+            if i % 2 == 0 {
+                level = levels[i % 3]
+                argv = []string { level, fmt.Sprint(i) }
+            }
+            result, err := schema.Parse(argv)
+            if err != nil {
+                errs <- err
+                return
+            }
+            if result.Get("level") != level || result.Get("count") != i {
+                errs <- fmt.Errorf("Parse %d got level %v and count %v", i, result.Get("level"), result.Get("count"))
+            }
+        }(i)
+    }
+    wg.Wait()
+    close(errs)
+
+    // Assertions
+
+    for err := range errs {
+        t.Error(err)
+    }
+}
+
+func TestSchemaConcurrentParse(t *testing.T) {
+
+    // Test setup
+
+    schema := NewSchema(func(ps *ParamSet) {
+        ps.Int("id", false, nil)
+        ps.StringList("words", true, nil)
+    })
+
+    // Test execution
+
+    var wg sync.WaitGroup
+    errs := make(chan error, 50)
+    for i := 0; i < 50; i++ {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+            words := []string { fmt.Sprint("w", i), fmt.Sprint("x", i) }
+            result, err := schema.Parse(append([]string { fmt.Sprint(i) }, words...))
+            if err != nil {
+                errs <- err
+                return
+            }
+            if result.Get("id") != i || !reflect.DeepEqual(result.Get("words"), words) {
+                errs <- fmt.Errorf("Parse %d got id %v and words %v", i, result.Get("id"), result.Get("words"))
+            }
+        }(i)
+    }
+    wg.Wait()
+    close(errs)
+
+    // Assertions
+
+    for err := range errs {
+        t.Error(err)
+    }
+}
+
+type lockedLogger struct {
+    mu sync.Mutex
+    warnings []string
+}
+
+func (l *lockedLogger) Printf(format string, v ...interface{}) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    l.warnings = append(l.warnings, fmt.Sprintf(format, v...))
+}
+
+func TestSchemaConcurrentParseDeprecated(t *testing.T) {
+
+    // Test setup
+
+    l := new(lockedLogger)
+    SetLogger(l)
+    defer SetLogger(nil)
+    schema := NewSchema(func(ps *ParamSet) {
+        ps.String("dest", false, nil)
+        ps.Param(Deprecated(NewParamSpec(new(StringValueList), "mode", true, nil), "Use --mode"))
+    })
+
+    // Test execution
+
+    var wg sync.WaitGroup
+    errs := make(chan error, 20)
+    for i := 0; i < 20; i++ {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+            mode := fmt.Sprint("m", i)
+            result, err := schema.Parse([]string { "a", mode })
+            if err != nil {
+                errs <- err
+                return
+            }
+            if !reflect.DeepEqual(result.Get("mode"), []string { mode }) {
+                errs <- fmt.Errorf("Parse %d got mode %v", i, result.Get("mode"))
+            }
+        }(i)
+    }
+    wg.Wait()
+    close(errs)
+
+    // Assertions
+
+    for err := range errs {
+        t.Error(err)
+    }
+    if len(l.warnings) != 20 { t.Errorf("Every parse should warn once, but the warnings were %q", l.warnings) }
+}
@@ -0,0 +1,179 @@
+package params
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+
+    "github.com/mauricelam/gocmdln/i18n"
+)
+
+// EditDistance returns the Damerau-Levenshtein distance between a and b, in its optimal string
+// alignment variant: the number of insertions, deletions, substitutions and transpositions of
+// adjacent characters needed to turn a into b.
+func EditDistance(a, b string) int {
+    s, t := []rune(a), []rune(b)
+    d := make([][]int, len(s) + 1)
+    for i := range d {
+        d[i] = make([]int, len(t) + 1)
+        d[i][0] = i
+    }
+    for j := range d[0] {
+        d[0][j] = j
+    }
+    for i := 1; i <= len(s); i++ {
+        for j := 1; j <= len(t); j++ {
+            cost := 1
+            if s[i-1] == t[j-1] { cost = 0 }
+            d[i][j] = minInt(d[i-1][j] + 1, d[i][j-1] + 1, d[i-1][j-1] + cost)
+            if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+                d[i][j] = minInt(d[i][j], d[i-2][j-2] + 1)
+            }
+        }
+    }
+    return d[len(s)][len(t)]
+}
+
+func minInt(first int, rest ...int) int {
+    for _, v := range rest {
+        if v < first { first = v }
+    }
+    return first
+}
+
+// Suggest returns the candidates that the input may be a misspelling of, most similar first. A
+// candidate is suggested if the input is a prefix of it, or if their EditDistance, ignoring case,
+// is at most a third of the length of the input, and at least 1 for inputs that are longer than
+// 1 character. For a single character, only the candidates starting with it are suggested, since
+// every other single character is 1 substitution away.
+func Suggest(input string, candidates []string) []string {
+    type suggestion struct {
+        candidate string
+        distance int
+    }
+    lowerInput := strings.ToLower(input)
+    length := len([]rune(input))
+    maxDistance := length / 3
+    if maxDistance < 1 && length > 1 { maxDistance = 1 }
+    var suggestions []suggestion
+    for _, candidate := range candidates {
+        if candidate == input {
+            continue
+        }
+        lowerCandidate := strings.ToLower(candidate)
+        distance := EditDistance(lowerInput, lowerCandidate)
+        if input != "" && strings.HasPrefix(lowerCandidate, lowerInput) {
+            // Prefixes are ranked before misspellings
+            distance = 0
+        }
+        if distance <= maxDistance {
+            suggestions = append(suggestions, suggestion{ candidate, distance })
+        }
+    }
+    sort.SliceStable(suggestions, func(i, j int) bool {
+        return suggestions[i].distance < suggestions[j].distance
+    })
+    var result []string
+    for _, s := range suggestions {
+        result = append(result, s.candidate)
+    }
+    return result
+}
+
+// DidYouMean formats the suggestions as a sentence to append to an error message, e.g.
+// ` Did you mean "status" or "stash"?`, or returns an empty string if there are no suggestions.
+// The suggestions are formatted using the format verb, e.g. "%q" or "--%s".
+func DidYouMean(format string, suggestions []string) string {
+    if len(suggestions) == 0 {
+        return ""
+    }
+    formatted := make([]string, len(suggestions))
+    for i, s := range suggestions {
+        formatted[i] = fmt.Sprintf(format, s)
+    }
+    list := formatted[0]
+    if len(formatted) > 1 {
+        list = i18n.Sprintf(i18n.ListOr, strings.Join(formatted[:len(formatted) - 1], ", "), formatted[len(formatted) - 1])
+    }
+    return " " + i18n.Sprintf(i18n.DidYouMean, list)
+}
+
+// SuggestionError is the error for a token that is not one of the known choices, for example an
+// invalid choice or an unknown subcommand. The Suggestions are rendered in the error message.
+type SuggestionError struct {
+    // Message describes the error, without the suggestions
+    Message string
+    Token string
+    Suggestions []string
+    // Format is the format verb of the suggestions in the message, "%q" if empty
+    Format string
+}
+
+func (e *SuggestionError) Error() string {
+    if len(e.Suggestions) == 0 {
+        return e.Message
+    }
+    format := e.Format
+    if format == "" { format = "%q" }
+    return e.Message + "." + DidYouMean(format, e.Suggestions)
+}
+
+// Is reports that a SuggestionError is a validation error, for errors.Is(err, ErrValidation).
+func (e *SuggestionError) Is(target error) bool {
+    return target == ErrValidation
+}
+
+// NewSuggestionError creates a SuggestionError for the token, suggesting the most similar of the
+// candidates.
+func NewSuggestionError(message string, token string, candidates []string) *SuggestionError {
+    return &SuggestionError{ Message: message, Token: token, Suggestions: Suggest(token, candidates) }
+}
+
+// -- choice Value
+type ChoiceValue struct {
+    value *string
+    choices []string
+    defaultValue string
+}
+
+func NewChoiceValue(val string, p *string, choices []string) *ChoiceValue {
+    *p = val
+    return &ChoiceValue{ p, choices, val }
+}
+
+// Reset sets the value back to the val passed to NewChoiceValue, or to "" for ClearValues.
+func (c *ChoiceValue) Reset(policy ResetPolicy) {
+    *c.value = c.defaultValue
+    if policy == ClearValues { *c.value = "" }
+}
+
+func (c *ChoiceValue) Set(s string) error {
+    for _, choice := range c.choices {
+        if s == choice {
+            *c.value = s
+            return nil
+        }
+    }
+    return NewSuggestionError(i18n.Sprintf(i18n.InvalidChoice, s, strings.Join(c.choices, ", ")), s, c.choices)
+}
+
+func (c *ChoiceValue) Get() interface{} { return *c.value }
+
+func (c *ChoiceValue) String() string { return *c.value }
+
+// Choices returns the valid values
+func (c *ChoiceValue) Choices() []string { return c.choices }
+
+// Choice creates a parameter of type string that must be one of the choices. If the argument is
+// not one of the choices, the error suggests the most similar ones.
+func (ps *ParamSet) Choice(name string, choices []string, optional bool, metadata interface{}) *string {
+    var tmp string
+    ps.VarValue(NewChoiceValue(tmp, &tmp, choices), name, optional, metadata)
+    return &tmp
+}
+
+// Choice creates a parameter of type string that must be one of the choices on the
+// DefaultParamSet.
+func Choice(name string, choices []string, optional bool, metadata interface{}) *string {
+    return defaultParamSet.Choice(name, choices, optional, metadata)
+}
@@ -0,0 +1,82 @@
+package params
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestEditDistance(t *testing.T) {
+    cases := []struct {
+        a, b string
+        distance int
+    }{
+        { "", "", 0 },
+        { "status", "status", 0 },
+        { "stauts", "status", 1 },
+        { "statsu", "status", 1 },
+        { "stat", "status", 2 },
+        { "kitten", "sitting", 3 },
+        { "", "abc", 3 },
+    }
+    for _, c := range cases {
+        if d := EditDistance(c.a, c.b); d != c.distance {
+            t.Errorf(`Distance between "%s" and "%s" should be %d, but was %d`, c.a, c.b, c.distance, d)
+        }
+    }
+}
+
+func TestSuggest(t *testing.T) {
+    candidates := []string { "status", "stash", "commit", "checkout", "cherry-pick" }
+    cases := map[string][]string {
+        "stauts": { "status" },
+        "sta": { "status", "stash" },
+        "Comit": { "commit" },
+        "chekout": { "checkout" },
+        "push": nil,
+        "status": nil,
+        "x": nil,
+        "c": { "commit", "checkout", "cherry-pick" },
+    }
+    for input, expected := range cases {
+        if suggestions := Suggest(input, candidates); !reflect.DeepEqual(suggestions, expected) {
+            t.Errorf(`Suggestions for "%s" should be %v, but were %v`, input, expected, suggestions)
+        }
+    }
+    if suggestions := Suggest("b", []string { "a", "c", "bc" }); !reflect.DeepEqual(suggestions, []string { "bc" }) {
+        t.Errorf("Single characters should only suggest prefixes, but were %v", suggestions)
+    }
+}
+
+func TestDidYouMean(t *testing.T) {
+    if s := DidYouMean("%q", nil); s != "" { t.Errorf("Unexpected: %s", s) }
+    if s := DidYouMean("%q", []string { "a" }); s != ` Did you mean "a"?` { t.Errorf("Unexpected: %s", s) }
+    if s := DidYouMean("--%s", []string { "a", "b", "c" }); s != ` Did you mean --a, --b or --c?` { t.Errorf("Unexpected: %s", s) }
+}
+
+func TestChoice(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    action := p.Choice("action", []string { "start", "stop", "status" }, false, nil)
+
+    t.Run("valid choice", func (t *testing.T) {
+        err := p.Parse([]string { "stop" })
+
+        // Assertions
+        if err != nil { t.Errorf("Unexpected error: %v", err) }
+        if *action != "stop" { t.Errorf(`action should be "stop", but was %s`, *action) }
+    })
+
+    t.Run("invalid choice", func (t *testing.T) {
+        err := p.Parse([]string { "stpo" })
+
+        // Assertions
+        expected := `Invalid choice "stpo", expected one of start, stop, status. Did you mean "stop"?`
+        if err == nil || err.Error() != expected { t.Errorf("Unexpected error: %v", err) }
+        argErr, ok := err.(*ArgumentError)
+        if !ok || !reflect.DeepEqual(argErr.Suggestions, []string { "stop" }) {
+            t.Errorf("Unexpected suggestions: %#v", err)
+        }
+    })
+}
@@ -0,0 +1,97 @@
+package params
+
+import (
+    "bytes"
+    "fmt"
+    "os"
+    "strings"
+    "text/tabwriter"
+)
+
+// DebugEnv is the environment variable that enables logging a Trace of every parse to the Logger.
+// For example, run the program with GOCMDLN_DEBUG=1 to see why an argument was assigned to a
+// parameter.
+const DebugEnv = "GOCMDLN_DEBUG"
+
+func debugEnabled() bool {
+    value := os.Getenv(DebugEnv)
+    return value != "" && value != "0"
+}
+
+// TraceStep records how the arguments were allocated to a single ParamSpec.
+type TraceStep struct {
+    Spec string
+    // MinLength is the result of MinLength in the first pass
+    MinLength int
+    // Reserved is the number of arguments at the end reserved by the Reservers after this spec
+    Reserved int
+    // Offered is the slice of argv passed to CaptureLength, or nil if it was not called
+    Offered []string
+    // Captured is the length returned by CaptureLength, or -1 if it was not called
+    Captured int
+    // Assigned is the final assignment of arguments to this spec
+    Assigned []string
+    // Err is the error returned by CaptureLength or Set
+    Err error
+}
+
+// Trace records each pass of the allocation of arguments to the ParamSpecs of a ParamSet.
+type Trace struct {
+    Argv []string
+    Steps []TraceStep
+    Err error
+}
+
+// ParseTrace parses argv like Parse, and also returns a Trace of how the arguments were allocated,
+// which can be printed to debug the assignment of arguments.
+func (ps *ParamSet) ParseTrace(argv []string) (*Trace, error) {
+    trace := new(Trace)
+    _, err := ps.parse(argv, false, trace)
+    return trace, err
+}
+
+// ParseTrace parses argv like Parse on the DefaultParamSet, and also returns a Trace of how the
+// arguments were allocated.
+func ParseTrace(argv []string) (*Trace, error) {
+    return defaultParamSet.ParseTrace(argv)
+}
+
+// String renders the trace as a table, for example
+//
+//   Parse ["a" "b" "c"]
+//   SPEC     MIN  RESERVED  OFFERED    CAPTURED  ASSIGNED   ERROR
+//   sources  1    0         ["a" "b"]  2         ["a" "b"]
+//   dest     1    0         ["c"]      1         ["c"]
+func (trace *Trace) String() string {
+    var table bytes.Buffer
+    w := tabwriter.NewWriter(&table, 0, 4, 2, ' ', 0)
+    fmt.Fprintln(w, "SPEC\tMIN\tRESERVED\tOFFERED\tCAPTURED\tASSIGNED\tERROR")
+    for _, step := range trace.Steps {
+        offered, captured, assigned, stepErr := "-", "-", "-", ""
+        if step.Offered != nil { offered = quoteArgs(step.Offered) }
+        if step.Captured >= 0 { captured = fmt.Sprint(step.Captured) }
+        if step.Assigned != nil { assigned = quoteArgs(step.Assigned) }
+        if step.Err != nil { stepErr = step.Err.Error() }
+        fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\t%s\n", step.Spec, step.MinLength, step.Reserved, offered, captured, assigned, stepErr)
+    }
+    w.Flush()
+    var buf bytes.Buffer
+    fmt.Fprintf(&buf, "Parse %s\n", quoteArgs(trace.Argv))
+    for _, line := range strings.SplitAfter(table.String(), "\n") {
+        // The empty error column leaves trailing spaces
+        buf.WriteString(strings.TrimRight(line, " \n"))
+        if strings.HasSuffix(line, "\n") { buf.WriteString("\n") }
+    }
+    if trace.Err != nil {
+        fmt.Fprintf(&buf, "Error: %v\n", trace.Err)
+    }
+    return buf.String()
+}
+
+func quoteArgs(args []string) string {
+    quoted := make([]string, len(args))
+    for i, arg := range args {
+        quoted[i] = fmt.Sprintf("%q", arg)
+    }
+    return "[" + strings.Join(quoted, " ") + "]"
+}
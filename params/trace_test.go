@@ -0,0 +1,74 @@
+package params
+
+import (
+    "os"
+    "reflect"
+    "testing"
+)
+
+func TestParseTrace(t *testing.T) {
+
+    // Test setup
+
+    p := new(ParamSet)
+    p.StringList("sources", false, nil)
+    p.String("dest", false, nil)
+    p.String("mode", true, nil)
+
+    // Test execution
+
+    trace, err := p.ParseTrace([]string { "a", "b", "c" })
+
+    // Assertions
+
+    if err != nil { t.Errorf("Unexpected error: %v", err) }
+    expected := []TraceStep {
+        { Spec: "sources", MinLength: 1, Offered: []string { "a", "b" }, Captured: 2, Assigned: []string { "a", "b" } },
+        { Spec: "dest", MinLength: 1, Offered: []string { "c" }, Captured: 1, Assigned: []string { "c" } },
+        { Spec: "mode", MinLength: 0, Captured: -1 },
+    }
+    if !reflect.DeepEqual(trace.Steps, expected) { t.Errorf("Unexpected steps: %#v", trace.Steps) }
+    table := `Parse ["a" "b" "c"]
+SPEC     MIN  RESERVED  OFFERED    CAPTURED  ASSIGNED   ERROR
+sources  1    0         ["a" "b"]  2         ["a" "b"]
+dest     1    0         ["c"]      1         ["c"]
+mode     0    0         -          -         -
+`
+    if trace.String() != table { t.Errorf("Unexpected table:\n%s", trace) }
+}
+
+func TestParseTraceError(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    p.Int("count", false, nil)
+
+    // Execution
+    trace, err := p.ParseTrace([]string { "x" })
+
+    // Assertions
+    if err == nil { t.Errorf("Error should not be nil") }
+    if trace.Err != err { t.Errorf("Trace error should be %v, but was %v", err, trace.Err) }
+    if trace.Steps[0].Err == nil { t.Errorf("Step error should not be nil") }
+}
+
+func TestDebugEnv(t *testing.T) {
+    // Setup
+    warnings, restore := useRecordingLogger()
+    defer restore()
+    os.Setenv(DebugEnv, "1")
+    defer os.Unsetenv(DebugEnv)
+    p := new(ParamSet)
+    p.String("command", false, nil)
+
+    // Execution
+    err := p.Parse([]string {})
+
+    // Assertions
+    expected := `Parse []
+SPEC     MIN  RESERVED  OFFERED  CAPTURED  ASSIGNED  ERROR
+command  1    0         -        -         -
+Error: Missing required argument "command"
+`
+    if err == nil { t.Errorf("Error should not be nil") }
+    if !reflect.DeepEqual([]string(*warnings), []string { expected }) { t.Errorf("Unexpected warnings: %q", *warnings) }
+}
@@ -0,0 +1,56 @@
+package params
+
+import (
+    "strings"
+)
+
+// Synopsizer can optionally be implemented by a ParamSpec to control how it is displayed in the
+// usage synopsis, e.g. "<command>" or "[<input-files>...]".
+type Synopsizer interface {
+    Synopsis() string
+}
+
+func (param *commonParamSpec) Synopsis() string {
+    s := "<" + displayName(param) + ">"
+    if param.maxLength != 1 {
+        s += "..."
+    }
+    if param.minLength == 0 {
+        s = "[" + s + "]"
+    }
+    return s
+}
+
+// SpecSynopsis returns the synopsis of a single ParamSpec. If the spec does not implement
+// Synopsizer, it is displayed as "<name>" if it is required or "[<name>]" otherwise, where the name
+// can be replaced by the Placeholder of its Help.
+func SpecSynopsis(paramSpec ParamSpec) string {
+    if s, ok := paramSpec.(Synopsizer); ok {
+        return s.Synopsis()
+    }
+    if paramSpec.MinLength() > 0 {
+        return "<" + displayName(paramSpec) + ">"
+    }
+    return "[<" + displayName(paramSpec) + ">]"
+}
+
+// Synopsis returns the usage synopsis of the positional parameters in the ParamSet, for example
+// "<command> [<input-files>...]". Hidden parameters are omitted.
+func (ps *ParamSet) Synopsis() string {
+    if ps == nil {
+        return ""
+    }
+    parts := make([]string, 0, len(*ps))
+    for _, paramSpec := range *ps {
+        if IsHidden(paramSpec) {
+            continue
+        }
+        parts = append(parts, SpecSynopsis(paramSpec))
+    }
+    return strings.Join(parts, " ")
+}
+
+// Synopsis returns the usage synopsis of the positional parameters in the DefaultParamSet.
+func Synopsis() string {
+    return defaultParamSet.Synopsis()
+}
@@ -0,0 +1,23 @@
+package params
+
+import (
+    "regexp"
+    "testing"
+)
+
+func TestSynopsis(t *testing.T) {
+    // Setup
+    p := new(ParamSet)
+    p.String("command", false, nil)
+    p.String("suffix", true, nil)
+    p.StringPattern("commit", regexp.MustCompile(`^[0-9a-f]+$`), false, nil)
+    p.StringList("inputFiles", true, nil)
+
+    // Execution
+    synopsis := p.Synopsis()
+
+    // Assertions
+    if expected := "<command> [<suffix>] <commit>... [<inputFiles>...]"; synopsis != expected {
+        t.Errorf(`Synopsis should be "%s", but was "%s"`, expected, synopsis)
+    }
+}
@@ -0,0 +1,137 @@
+// Package paramstest provides utilities for testing command line definitions built with the params
+// and options packages: table-driven parse checks, golden files for generated usage and help, and
+// a runner that captures the output and exit code of a whole command.
+package paramstest
+
+import (
+    "bytes"
+    "flag"
+    "fmt"
+    "io"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "reflect"
+    "testing"
+
+    "github.com/mauricelam/gocmdln/params"
+)
+
+// update is namespaced, so that it does not conflict with an -update flag of the test binary
+var update = flag.Bool("paramstest.update", false, "update the golden files of paramstest instead of comparing them")
+
+// ParseCase is a single case of a parse table.
+type ParseCase struct {
+    // Name of the subtest. If empty, the argv is used.
+    Name string
+    Argv []string
+    // Want is compared to the parsed values using reflect.DeepEqual. It is not checked if parsing
+    // fails.
+    Want interface{}
+    // Err is the expected error message, or an empty string if parsing should succeed
+    Err string
+}
+
+// Setup creates a new ParamSet, along with a function that returns the parsed values, for example
+// as a struct or a slice. It is called once for every ParseCase, so cases do not share values.
+type Setup func() (ps *params.ParamSet, values func() interface{})
+
+// RunParseCases parses the argv of each case with a ParamSet created by setup, in a subtest for
+// each case, and checks the error and the parsed values.
+func RunParseCases(t *testing.T, setup Setup, cases []ParseCase) {
+    for _, c := range cases {
+        c := c
+        name := c.Name
+        if name == "" { name = fmt.Sprintf("%q", c.Argv) }
+        t.Run(name, func(t *testing.T) {
+            ps, values := setup()
+            err := ps.Parse(c.Argv)
+            if c.Err != "" {
+                if err == nil {
+                    t.Errorf(`Parsing %q should fail with "%s", but succeeded`, c.Argv, c.Err)
+                } else if err.Error() != c.Err {
+                    t.Errorf(`Parsing %q should fail with "%s", but failed with "%s"`, c.Argv, c.Err, err)
+                }
+                return
+            }
+            if err != nil {
+                t.Errorf("Parsing %q failed: %v", c.Argv, err)
+                return
+            }
+            if got := values(); !reflect.DeepEqual(got, c.Want) {
+                t.Errorf("Parsing %q should give %#v, but was %#v", c.Argv, c.Want, got)
+            }
+        })
+    }
+}
+
+// Golden compares got to the golden file testdata/<name>.golden. If the test is run with the
+// -paramstest.update flag, the golden file is written instead.
+func Golden(t testing.TB, name string, got []byte) {
+    t.Helper()
+    path := filepath.Join("testdata", name + ".golden")
+    if *update {
+        if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil { t.Fatal(err) }
+        if err := ioutil.WriteFile(path, got, 0644); err != nil { t.Fatal(err) }
+        return
+    }
+    want, err := ioutil.ReadFile(path)
+    if err != nil {
+        t.Fatalf("Cannot read golden file, run the test with -paramstest.update to create it: %v", err)
+    }
+    if !bytes.Equal(got, want) {
+        t.Errorf("Output does not match %s, run the test with -paramstest.update to update it.\nGot:\n%s\nWant:\n%s", path, got, want)
+    }
+}
+
+// GoldenString is like Golden, but for a string.
+func GoldenString(t testing.TB, name string, got string) {
+    t.Helper()
+    Golden(t, name, []byte(got))
+}
+
+// Env is the environment of a command run by Run, replacing os.Stdout, os.Stderr and os.Exit.
+type Env struct {
+    Stdout io.Writer
+    Stderr io.Writer
+    // Exit stops the command with the exit code, like os.Exit. It must be called from the
+    // goroutine of the command.
+    Exit func(code int)
+}
+
+// Result is the outcome of a command run by Run.
+type Result struct {
+    Stdout string
+    Stderr string
+    // ExitCode is the code passed to Exit, or 0 if the command returned without calling Exit
+    ExitCode int
+    // Exited is whether the command called Exit
+    Exited bool
+}
+
+type exitPanic struct {
+    code int
+}
+
+// Run runs the command main with argv and a fake Env, and returns the captured output and the exit
+// code. Calling Env.Exit stops the command, like os.Exit would, but without exiting the test.
+func Run(argv []string, main func(env *Env, argv []string)) (result Result) {
+    var stdout, stderr bytes.Buffer
+    env := &Env{
+        Stdout: &stdout,
+        Stderr: &stderr,
+        Exit: func(code int) { panic(exitPanic{ code }) },
+    }
+    defer func() {
+        if r := recover(); r != nil {
+            exit, ok := r.(exitPanic)
+            if !ok { panic(r) }
+            result.ExitCode = exit.code
+            result.Exited = true
+        }
+        result.Stdout = stdout.String()
+        result.Stderr = stderr.String()
+    }()
+    main(env, argv)
+    return
+}
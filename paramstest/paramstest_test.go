@@ -0,0 +1,72 @@
+package paramstest
+
+import (
+    "bytes"
+    "fmt"
+    "testing"
+
+    "github.com/mauricelam/gocmdln/i18n"
+    "github.com/mauricelam/gocmdln/options"
+    "github.com/mauricelam/gocmdln/params"
+)
+
+func init() {
+    // The tests compare the English messages, regardless of the locale of the environment
+    i18n.SetLocale("en")
+}
+
+type copyArgs struct {
+    Sources []string
+    Dest string
+}
+
+func setupCopy() (*params.ParamSet, func() interface{}) {
+    ps := new(params.ParamSet)
+    sources := ps.StringList("sources", false, params.Help{ Short: "the files to copy", Placeholder: "SOURCE" })
+    dest := ps.String("dest", false, params.Help{ Short: "the destination directory", Placeholder: "DEST" })
+    return ps, func() interface{} { return copyArgs{ *sources, *dest } }
+}
+
+func TestRunParseCases(t *testing.T) {
+    RunParseCases(t, setupCopy, []ParseCase {
+        { Argv: []string { "a", "dir" }, Want: copyArgs{ []string { "a" }, "dir" } },
+        { Name: "multiple sources", Argv: []string { "a", "b", "dir" }, Want: copyArgs{ []string { "a", "b" }, "dir" } },
+        { Name: "no arguments", Argv: []string {}, Err: `Missing required argument "sources"` },
+    })
+}
+
+func TestGoldenUsage(t *testing.T) {
+    ps, _ := setupCopy()
+    opts := new(options.OptionSet)
+    opts.Bool("force", 'f', "overwrite existing files")
+
+    var buf bytes.Buffer
+    options.PrintUsage(&buf, "cp", opts, ps)
+
+    Golden(t, "cp-usage", buf.Bytes())
+}
+
+func copyMain(env *Env, argv []string) {
+    ps, values := setupCopy()
+    if err := ps.Parse(argv); err != nil {
+        fmt.Fprintln(env.Stderr, err)
+        env.Exit(2)
+    }
+    args := values().(copyArgs)
+    fmt.Fprintf(env.Stdout, "Copying %v to %s\n", args.Sources, args.Dest)
+}
+
+func TestRun(t *testing.T) {
+    // Execution
+    success := Run([]string { "a", "dir" }, copyMain)
+    failure := Run([]string {}, copyMain)
+
+    // Assertions
+    if success != (Result{ Stdout: "Copying [a] to dir\n" }) {
+        t.Errorf("Unexpected result: %#v", success)
+    }
+    expected := Result{ Stderr: "Missing required argument \"sources\"\n", ExitCode: 2, Exited: true }
+    if failure != expected {
+        t.Errorf("Unexpected result: %#v", failure)
+    }
+}